@@ -0,0 +1,39 @@
+package biquad
+
+import "math"
+
+// AllPass is an all pass filter. It has unity gain at all
+// frequencies and shifts phase around the working frequency.
+type AllPass struct {
+	blt
+}
+
+// NewAllPass creates an all pass filter from
+//  Fs: sampling frequency
+//  f0: working frequency, where phase shift is -180 degrees
+//  Q: quality factor. Higher Q yields a steeper phase transition around f0
+//     and a larger group delay peak at f0.
+func NewAllPass(Fs, f0, Q float64) (*AllPass, error) {
+	switch {
+	case f0 >= Fs:
+		return nil, ErrBadWorkingFreq
+	case Q <= 0:
+		return nil, ErrBadQ
+	case f0 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	}
+	w0 := 2 * math.Pi * (f0 / Fs)
+	alpha := alphaCalc{}.q(w0, Q)
+	cos := math.Cos(w0)
+	var (
+		b0 = 1 - alpha
+		b1 = -2 * cos
+		b2 = 1 + alpha
+		a0 = 1 + alpha
+		a1 = -2 * cos
+		a2 = 1 - alpha
+	)
+	return &AllPass{
+		blt: newBLT(a0, a1, a2, b0, b1, b2),
+	}, nil
+}
@@ -0,0 +1,37 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestAllPassGroupDelay(t *testing.T) {
+	const (
+		fs = 48e3
+		f0 = 2e3
+		Q  = 4.
+		h  = 1e-3 // Hz step for numerical phase derivative.
+	)
+	ap, err := NewAllPass(fs, f0, Q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	H := ap.getH()
+	freqs := []float64{20, 500, 1e3, f0, 5e3, 15e3}
+	gd := ap.GroupDelay(fs, freqs)
+	for i, f := range freqs {
+		// tau = -dphi/dw where w is in rad/s.
+		p1 := cmplx.Phase(H(cmplx.Rect(1, 2*math.Pi*(f-h)/fs)))
+		p2 := cmplx.Phase(H(cmplx.Rect(1, 2*math.Pi*(f+h)/fs)))
+		dphi := math.Remainder(p2-p1, 2*math.Pi)
+		want := -dphi / (2 * math.Pi * 2 * h)
+		if math.Abs(gd[i]-want) > 1e-6*math.Abs(want)+1e-9 {
+			t.Errorf("group delay at %gHz: got %gs, numerical derivative %gs", f, gd[i], want)
+		}
+	}
+	// Delay should peak around the working frequency.
+	if gd[3] < gd[0] || gd[3] < gd[5] {
+		t.Errorf("expected group delay peak around f0, got %v", gd)
+	}
+}
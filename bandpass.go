@@ -21,10 +21,53 @@ func NewBandPass(Fs, f0, BW float64) (*BandPass, error) {
 		return nil, ErrBadFreq
 	}
 	w0 := 2 * math.Pi * (f0 / Fs)
-	cos := math.Cos(w0)
 	alpha := alphaCalc{}.bw(w0, BW)
+	return newBandPass(w0, alpha, alpha), nil
+}
+
+// NewBandPassQ creates a band pass filter from
+//  Fs: sampling frequency
+//  f0: working frequency
+//  Q: quality factor. Higher Q yields a narrower pass band.
+// This filter has constant peak gain (0 dB).
+func NewBandPassQ(Fs, f0, Q float64) (*BandPass, error) {
+	switch {
+	case f0 >= Fs:
+		return nil, ErrBadWorkingFreq
+	case Q <= 0:
+		return nil, ErrBadQ
+	case f0 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	}
+	w0 := 2 * math.Pi * (f0 / Fs)
+	alpha := alphaCalc{}.q(w0, Q)
+	return newBandPass(w0, alpha, alpha), nil
+}
+
+// NewBandPassSkirtQ creates a band pass filter from
+//  Fs: sampling frequency
+//  f0: working frequency
+//  Q: quality factor. Higher Q yields a narrower pass band.
+// This filter has constant skirt gain. Peak gain is Q.
+func NewBandPassSkirtQ(Fs, f0, Q float64) (*BandPass, error) {
+	switch {
+	case f0 >= Fs:
+		return nil, ErrBadWorkingFreq
+	case Q <= 0:
+		return nil, ErrBadQ
+	case f0 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	}
+	w0 := 2 * math.Pi * (f0 / Fs)
+	alpha := alphaCalc{}.q(w0, Q)
+	return newBandPass(w0, alpha, Q*alpha), nil
+}
+
+// newBandPass creates a band pass filter with numerator gain b0.
+// b0 == alpha yields constant 0 dB peak gain, b0 == sin(w0)/2 yields constant skirt gain.
+func newBandPass(w0, alpha, b0 float64) *BandPass {
+	cos := math.Cos(w0)
 	var (
-		b0 = alpha
 		b1 = 0.
 		b2 = -b0
 		a0 = 1 + alpha
@@ -33,7 +76,7 @@ func NewBandPass(Fs, f0, BW float64) (*BandPass, error) {
 	)
 	return &BandPass{
 		blt: newBLT(a0, a1, a2, b0, b1, b2),
-	}, nil
+	}
 }
 
 // NewBandPass creates a band pass filter from
@@ -0,0 +1,141 @@
+package biquad
+
+import "math/cmplx"
+
+// Bank is a parallel filter bank. Each sample is processed by all
+// band filters independently. The output of each band may be read separately
+// with Band or combined as a weighted sum with YNext.
+// Bank implements RecursiveFilter so it may be used in a Cascade.
+type Bank struct {
+	filters []RecursiveFilter
+	weights []float64
+}
+
+// NewBank creates a filter bank with one band per filter.
+// All bands have unity weight.
+func NewBank(filters ...RecursiveFilter) *Bank {
+	b := &Bank{}
+	b.Append(filters...)
+	return b
+}
+
+// Append adds bands with unity weight to the bank.
+func (b *Bank) Append(filters ...RecursiveFilter) {
+	for range filters {
+		b.weights = append(b.weights, 1)
+	}
+	b.filters = append(b.filters, filters...)
+}
+
+// Len returns the number of bands in the bank.
+func (b *Bank) Len() int { return len(b.filters) }
+
+// SetWeight sets the weight of the i-th band in the summed output.
+// A weight of 0 mutes the band.
+func (b *Bank) SetWeight(i int, w float64) { b.weights[i] = w }
+
+// Weight returns the weight of the i-th band in the summed output.
+func (b *Bank) Weight(i int) float64 { return b.weights[i] }
+
+// DiscreteProcess takes in the next signal data point
+// and processes it through all bands.
+func (b *Bank) DiscreteProcess(x float64) {
+	for _, f := range b.filters {
+		f.DiscreteProcess(x)
+	}
+}
+
+// YNext returns the weighted sum of the last results of all bands given by DiscreteProcess.
+func (b *Bank) YNext() (y float64) {
+	for i, f := range b.filters {
+		y += b.weights[i] * f.YNext()
+	}
+	return y
+}
+
+// Band returns the last result of the i-th band given by DiscreteProcess. The
+// band weight is not applied.
+func (b *Bank) Band(i int) float64 {
+	return b.filters[i].YNext()
+}
+
+// Bands stores the last results of all bands in dst and returns it.
+// If dst does not have enough capacity a new slice is allocated.
+func (b *Bank) Bands(dst []float64) []float64 {
+	if cap(dst) < len(b.filters) {
+		dst = make([]float64, len(b.filters))
+	}
+	dst = dst[:len(b.filters)]
+	for i, f := range b.filters {
+		dst[i] = f.YNext()
+	}
+	return dst
+}
+
+// Filter processes a digital signal through the bank and returns the
+// weighted sum of the bands. Processing continues from the current state
+// of each band filter. The length of the data must be greater than 2.
+func (b *Bank) Filter(signal Signal) (Signal, error) {
+	N := signal.Len()
+	if N < 3 {
+		return nil, ErrShortXY
+	}
+	fval := make([]float64, N)
+	for i := 0; i < N; i++ {
+		_, x := signal.XY(i)
+		b.DiscreteProcess(x)
+		fval[i] = b.YNext()
+	}
+	return filtered{
+		Signal: signal,
+		fval:   fval,
+	}, nil
+}
+
+// FilterBands processes a digital signal through the bank and returns the
+// output of each band separately. Band weights are not applied. Processing
+// continues from the current state of each band filter. The length of the data must be greater than 2.
+func (b *Bank) FilterBands(signal Signal) ([]Signal, error) {
+	N := signal.Len()
+	if N < 3 {
+		return nil, ErrShortXY
+	}
+	fvals := make([][]float64, len(b.filters))
+	for i := range fvals {
+		fvals[i] = make([]float64, N)
+	}
+	for i := 0; i < N; i++ {
+		_, x := signal.XY(i)
+		for j, f := range b.filters {
+			f.DiscreteProcess(x)
+			fvals[j][i] = f.YNext()
+		}
+	}
+	bands := make([]Signal, len(b.filters))
+	for i := range bands {
+		bands[i] = filtered{Signal: signal, fval: fvals[i]}
+	}
+	return bands, nil
+}
+
+// getH returns the transfer function of the summed output of the bank, the
+// weighted sum of the transfer functions of its bands. If a band does not
+// implement Responder the result is NaN.
+func (b Bank) getH() func(z complex128) complex128 {
+	hs := make([]func(complex128) complex128, len(b.filters))
+	for i, f := range b.filters {
+		H, ok := transferFunc(f)
+		if !ok {
+			return func(complex128) complex128 { return cmplx.NaN() }
+		}
+		hs[i] = H
+	}
+	weights := append([]float64{}, b.weights...)
+	return func(z complex128) complex128 {
+		var h complex128
+		for i := range hs {
+			h += complex(weights[i], 0) * hs[i](z)
+		}
+		return h
+	}
+}
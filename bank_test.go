@@ -0,0 +1,111 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestBank(t *testing.T) {
+	const (
+		fs = 48e3
+		f1 = 300.
+		f2 = 3e3
+	)
+	// Three band LR4 crossover. The low and mid bands go through an all pass
+	// matching the phase of the other crossover so the bands sum flat.
+	lp1, hp1, err := NewLinkwitzRiley(fs, f1, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lp2, hp2, err := NewLinkwitzRiley(fs, f2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	apLP, apHP, err := NewLinkwitzRiley(fs, f2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, hp1b, err := NewLinkwitzRiley(fs, f1, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	low := NewCascade(lp1, NewBank(apLP, apHP))
+	mid := NewCascade(hp1, lp2)
+	high := NewCascade(hp1b, hp2)
+	bank := NewBank(low, mid, high)
+	H := bank.getH()
+	for _, f := range []float64{10, 100, f1, 1e3, f2, 10e3, 20e3} {
+		if got := cmplx.Abs(H(cmplx.Rect(1, 2*math.Pi*f/fs))); math.Abs(got-1) > 1e-9 {
+			t.Errorf("expected bank to reconstruct flat at %gHz, got gain %g", f, got)
+		}
+	}
+	// Muting the high band leaves a low pass response.
+	bank.SetWeight(2, 0)
+	if got := cmplx.Abs(bank.getH()(-1)); got > 1e-6 {
+		t.Errorf("expected muted high band to remove Nyquist content, got gain %g", got)
+	}
+	// The response of the bank is the weighted sum of the responses of its bands.
+	bank.SetWeight(1, 0.5)
+	freqs := []float64{10, f1, 1e3, f2, 20e3}
+	h := bank.Response(fs, freqs)
+	hl, hm, hh := low.Response(fs, freqs), mid.Response(fs, freqs), high.Response(fs, freqs)
+	for i, f := range freqs {
+		if want := hl[i] + 0.5*hm[i] + 0*hh[i]; cmplx.Abs(h[i]-want) > 1e-12 {
+			t.Errorf("response at %gHz: expected %v, got %v", f, want, h[i])
+		}
+	}
+
+	data := make([]float64, 200)
+	for i := range data {
+		data[i] = math.Sin(2*math.Pi*1e3*float64(i)/fs) + math.Sin(2*math.Pi*50*float64(i)/fs)
+	}
+	b1, err := NewButterworthLPN(fs, 500, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b2, err := NewButterworthHPN(fs, 500, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bank = NewBank(b1, b2)
+	bank.SetWeight(1, 0.5)
+	var dst []float64
+	for i := range data {
+		bank.DiscreteProcess(data[i])
+		dst = bank.Bands(dst)
+		if got, want := bank.YNext(), dst[0]+0.5*dst[1]; got != want {
+			t.Fatalf("sample %d: expected weighted sum %g, got %g", i, want, got)
+		}
+	}
+
+	// Each band of FilterBands matches its filter run alone, without weights.
+	newBands := func() []RecursiveFilter {
+		lp, _ := NewButterworthLPN(fs, 500, 2)
+		bp, _ := NewBandPass(fs, 1e3, 1)
+		hp, _ := NewHighPass(fs, 5e3, 1)
+		return []RecursiveFilter{lp, bp, hp}
+	}
+	bank = NewBank(newBands()...)
+	bank.SetWeight(0, 3)
+	signal := MakeSignal(fs, data)
+	bands, err := bank.FilterBands(signal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bands) != bank.Len() {
+		t.Fatalf("expected %d bands, got %d", bank.Len(), len(bands))
+	}
+	for j, f := range newBands() {
+		for i := range data {
+			f.DiscreteProcess(data[i])
+			tw, _ := signal.XY(i)
+			if tg, got := bands[j].XY(i); got != f.YNext() || tg != tw {
+				t.Fatalf("band %d sample %d: expected (%g, %g), got (%g, %g)", j, i, tw, f.YNext(), tg, got)
+			}
+		}
+	}
+	if _, err := bank.FilterBands(MakeSignal(fs, data[:2])); err != ErrShortXY {
+		t.Errorf("expected ErrShortXY, got %v", err)
+	}
+}
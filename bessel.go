@@ -0,0 +1,165 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Bessel-Thomson filters have a maximally flat group delay in the passband,
+// which preserves the shape of waveforms: step responses show little to no overshoot.
+// The normalized low pass transfer function is
+//  H(s) = theta_n(0) / theta_n(s)
+// where theta_n is the reverse Bessel polynomial of order n
+//  theta_n(s) = SUM_{k=0}^{n} a_k * s^k    a_k = (2n-k)! / (2^(n-k) * k! * (n-k)!)
+// The roots of theta_n give a filter with unity group delay at DC. The poles
+// are then scaled according to the chosen cutoff frequency normalization.
+// Note the bilinear transformation does not preserve the flat group delay exactly.
+// The approximation is good well below the Nyquist frequency.
+
+// BesselNorm selects the meaning of the cutoff frequency of a Bessel filter.
+type BesselNorm int
+
+const (
+	// BesselPhase normalizes the filter so the high frequency asymptote of the gain
+	// matches that of a Butterworth filter of the same order and cutoff. For low
+	// orders the phase response at the cutoff frequency is close to its midpoint -n*pi/4.
+	BesselPhase BesselNorm = iota
+	// BesselMag normalizes the filter so the gain at the cutoff frequency is -3dB.
+	BesselMag
+	// BesselDelay normalizes the filter so the group delay at DC is 1/(2*pi*fc).
+	BesselDelay
+)
+
+// NewBesselLP creates a low pass Bessel filter of arbitrary order from
+//  Fs: sampling frequency
+//  fc: cutoff frequency. Its meaning depends on norm.
+//  n: filter order
+//  norm: cutoff frequency normalization.
+// The filter is implemented as a cascade of second order sections and
+// has unity gain at DC.
+func NewBesselLP(Fs, fc float64, n int, norm BesselNorm) (*SOS, error) {
+	switch {
+	case n < 1:
+		return nil, ErrBadOrder
+	case fc <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	case fc >= Fs/2:
+		return nil, ErrAboveNyquist
+	}
+	wc := prewarp(Fs, fc)
+	return besselPrototype(n, norm).LPToLP(wc).bilinear(Fs).sos(), nil
+}
+
+// NewBesselHP creates a high pass Bessel filter of arbitrary order from
+//  Fs: sampling frequency
+//  fc: cutoff frequency. Its meaning depends on norm.
+//  n: filter order
+//  norm: cutoff frequency normalization.
+// The filter is implemented as a cascade of second order sections and
+// has unity gain at the Nyquist frequency.
+func NewBesselHP(Fs, fc float64, n int, norm BesselNorm) (*SOS, error) {
+	switch {
+	case n < 1:
+		return nil, ErrBadOrder
+	case fc <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	case fc >= Fs/2:
+		return nil, ErrAboveNyquist
+	}
+	wc := prewarp(Fs, fc)
+	return besselPrototype(n, norm).LPToHP(wc).bilinear(Fs).sos(), nil
+}
+
+// BesselPrototype returns the analog low pass Bessel prototype of order n
+// with a cutoff frequency of 1 rad/s according to norm.
+// Invalid parameters return ErrBadOrder.
+func BesselPrototype(n int, norm BesselNorm) (ZPK, error) {
+	if n < 1 {
+		return ZPK{}, ErrBadOrder
+	}
+	return besselPrototype(n, norm), nil
+}
+
+// besselPrototype is BesselPrototype without parameter validation.
+func besselPrototype(n int, norm BesselNorm) ZPK {
+	// Coefficients in descending powers: c[i] = a_{n-i}.
+	// a_n = 1 and a_k/a_{k+1} = (2n-k)*(k+1) / (2*(n-k)).
+	c := make([]complex128, n+1)
+	a := 1.
+	c[0] = 1
+	for k := n - 1; k >= 0; k-- {
+		a *= float64((2*n-k)*(k+1)) / float64(2*(n-k))
+		c[n-k] = complex(a, 0)
+	}
+	p := polyRoots(c)
+	switch norm {
+	case BesselPhase:
+		// a_0 = theta_n(0) = PROD(-p_i). Scaling by a_0^(-1/n) yields PROD(-p_i) = 1.
+		scale := complex(math.Pow(a, -1/float64(n)), 0)
+		for i := range p {
+			p[i] *= scale
+		}
+	case BesselMag:
+		scale := complex(1/besselCutoff3dB(p), 0)
+		for i := range p {
+			p[i] *= scale
+		}
+	}
+	forceConjugates(p)
+	return ZPK{P: p, K: real(prodNeg(p))}
+}
+
+// besselCutoff3dB finds the frequency w where the all-pole low pass filter with
+// poles p and unity DC gain has gain 1/sqrt(2). The gain is monotonically decreasing
+// so the frequency is found by bisection.
+func besselCutoff3dB(p []complex128) float64 {
+	gain2 := func(w float64) float64 {
+		g := 1.
+		for i := range p {
+			g *= cmplx.Abs(p[i]) / cmplx.Abs(complex(0, w)-p[i])
+		}
+		return g * g
+	}
+	lo, hi := 0., 1.
+	for gain2(hi) > 0.5 {
+		lo, hi = hi, 2*hi
+	}
+	for i := 0; i < 100 && hi-lo > 1e-15*hi; i++ {
+		mid := (lo + hi) / 2
+		if gain2(mid) > 0.5 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// forceConjugates cleans up numerically computed roots of a real polynomial
+// so conjugate pairs are exact conjugates and real roots have no imaginary part.
+func forceConjugates(r []complex128) {
+	const tol = 1e-9
+	for i := range r {
+		if math.Abs(imag(r[i])) <= tol*math.Max(1, cmplx.Abs(r[i])) {
+			r[i] = complex(real(r[i]), 0)
+			continue
+		}
+		if imag(r[i]) < 0 {
+			continue
+		}
+		// Find closest conjugate partner.
+		best, bestDist := -1, math.Inf(1)
+		for j := range r {
+			if imag(r[j]) >= 0 {
+				continue
+			}
+			if d := cmplx.Abs(r[j] - cmplx.Conj(r[i])); d < bestDist {
+				best, bestDist = j, d
+			}
+		}
+		if best >= 0 {
+			avg := (r[i] + cmplx.Conj(r[best])) / 2
+			r[i], r[best] = avg, cmplx.Conj(avg)
+		}
+	}
+}
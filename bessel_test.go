@@ -0,0 +1,107 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestBesselPrototype(t *testing.T) {
+	const tol = 1e-9
+	// Reference poles for the phase normalized second and third order prototypes.
+	for _, test := range []struct {
+		n    int
+		want []complex128
+	}{
+		{2, []complex128{complex(-math.Sqrt(3)/2, 0.5), complex(-math.Sqrt(3)/2, -0.5)}},
+		{3, []complex128{-0.9416000265332067, -0.7456403858480766 + 0.7113666249728353i, -0.7456403858480766 - 0.7113666249728353i}},
+	} {
+		h, err := BesselPrototype(test.n, BesselPhase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, w := range test.want {
+			found := false
+			for _, p := range h.P {
+				found = found || cmplx.Abs(p-w) < tol
+			}
+			if !found {
+				t.Errorf("n=%d: pole %v not found in %v", test.n, w, h.P)
+			}
+		}
+	}
+	H := func(h ZPK, w float64) complex128 {
+		s := complex(0, w)
+		g := complex(h.K, 0)
+		for _, p := range h.P {
+			g /= s - p
+		}
+		return g
+	}
+	for n := 1; n <= 12; n++ {
+		phase, err := BesselPrototype(n, BesselPhase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Gain asymptote k/w^n matches Butterworth's 1/w^n.
+		if math.Abs(phase.K-1) > 1e-9 {
+			t.Errorf("n=%d phase norm: expected unity asymptotic gain, got %g", n, phase.K)
+		}
+		if got, want := cmplx.Phase(H(phase, 1)), -float64(n)*math.Pi/4; n <= 4 && math.Abs(math.Remainder(got-want, 2*math.Pi)) > 0.05 {
+			t.Errorf("n=%d phase norm: expected phase near %g at cutoff, got %g", n, want, got)
+		}
+		mag, err := BesselPrototype(n, BesselMag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := cmplx.Abs(H(mag, 1)); math.Abs(got-math.Sqrt2/2) > 1e-9 {
+			t.Errorf("n=%d mag norm: expected -3dB at cutoff, got gain %g", n, got)
+		}
+		delay, err := BesselPrototype(n, BesselDelay)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Group delay at DC: SUM(-1/p_i) for an all pole filter.
+		var gd float64
+		for _, p := range delay.P {
+			gd += real(-1 / p)
+		}
+		if math.Abs(gd-1) > 1e-9 {
+			t.Errorf("n=%d delay norm: expected unity group delay at DC, got %g", n, gd)
+		}
+	}
+}
+
+func TestBesselStep(t *testing.T) {
+	const (
+		fs = 1e3
+		fc = 20.
+		n  = 4
+	)
+	overshoot := func(f RecursiveFilter) float64 {
+		max := 0.
+		for i := 0; i < 2000; i++ {
+			f.DiscreteProcess(1)
+			max = math.Max(max, f.YNext())
+		}
+		return max - 1
+	}
+	bessel, err := NewBesselLP(fs, fc, n, BesselMag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	butter, err := NewButterworthLPN(fs, fc, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 4th order Bessel overshoot is under 1% while Butterworth overshoots around 10%.
+	if got := overshoot(bessel); got > 0.01 {
+		t.Errorf("expected Bessel overshoot under 1%%, got %g%%", got*100)
+	}
+	if got := overshoot(butter); got < 0.05 {
+		t.Errorf("expected Butterworth overshoot over 5%%, got %g%%", got*100)
+	}
+	if got := 20 * math.Log10(cmplx.Abs(bessel.getH()(cmplx.Rect(1, 2*math.Pi*fc/fs)))); math.Abs(got-10*math.Log10(0.5)) > 1e-9 {
+		t.Errorf("expected -3dB at cutoff, got %gdB", got)
+	}
+}
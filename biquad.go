@@ -0,0 +1,19 @@
+package biquad
+
+// Biquad is a generic second order filter defined directly by its coefficients
+//  H(z) = (b_0 + b_1*z^{-1} + b_2*z^{-2}) / (a_0 + a_1*z^{-1} + a_2*z^{-2})
+type Biquad struct {
+	blt
+}
+
+// NewBiquad creates a biquad filter from the coefficients of its transfer function
+//  H(z) = (b0 + b1*z^{-1} + b2*z^{-2}) / (a0 + a1*z^{-1} + a2*z^{-2})
+// a0 must not be zero.
+func NewBiquad(b0, b1, b2, a0, a1, a2 float64) (*Biquad, error) {
+	if a0 == 0 {
+		return nil, ErrBadCoefficients
+	}
+	return &Biquad{
+		blt: newBLT(a0, a1, a2, b0, b1, b2),
+	}, nil
+}
@@ -1,5 +1,10 @@
 package biquad
 
+import (
+	"math"
+	"math/cmplx"
+)
+
 // Bilinear transform for filter use.
 // Comes from the following biquad transfer function (see http://shepazu.github.io/Audio-EQ-Cookbook/audio-eq-cookbook.html):
 //  H(z) = (b_0 + b_1*z^{-1} + b_2*z^{-2}) / (a_0 + a_1*z^{-1} + a_2*z^{-2})
@@ -10,6 +15,10 @@ type blt struct {
 	x, y [3]float64
 	// points to `n` index in ring buffer.
 	ptr uint
+	// Initial conditions used by Filter.
+	initMode InitMode
+	// Custom initial input and output history: x[n-1], x[n-2] and y[n-1], y[n-2].
+	xi, yi [2]float64
 }
 
 //  H(z) = (b_0 + b_1*z^{-1} + b_2*z^{-2}) / (a_0 + a_1*z^{-1} + a_2*z^{-2})
@@ -42,22 +51,26 @@ func (b *blt) advance(x float64) {
 }
 
 func (b *blt) ynext() float64 {
-	return b.y[b.ptr%3]
+	return b.y[(b.ptr-1)%3]
 }
 
+// init initializes the filter state before filtering the signal
+// according to the initial condition mode of the filter.
 func (b *blt) init(xy Signal) {
-	x, y := xy.XY(0)
-	b.x[0] = x
-	b.x[1] = x
-	b.x[2] = x
-
-	b.y[0] = y
-	b.y[1] = y
-	b.y[2] = y
+	switch b.initMode {
+	case InitZero:
+		b.setHistory([2]float64{}, [2]float64{})
+	case InitCustom:
+		b.setHistory(b.xi, b.yi)
+	default:
+		_, x0 := xy.XY(0)
+		b.steadyState(x0)
+	}
 }
 
 // Filter applies a bilinear transformation filter to a digital
 // signal and returns the filtered result. The length of the data must be greater than 2.
+// The filter state is first initialized according to the mode set with SetInit.
 func (b *blt) Filter(signal Signal) (Signal, error) {
 	var x float64
 	N := signal.Len()
@@ -89,3 +102,28 @@ func (b *blt) DiscreteProcess(x float64) {
 func (b *blt) YNext() (y float64) {
 	return b.ynext()
 }
+
+// GroupDelay returns the group delay of the filter in seconds at
+// frequencies f (in Hz) for a sampling frequency Fs.
+func (b *blt) GroupDelay(Fs float64, f []float64) []float64 {
+	gd := make([]float64, len(f))
+	for i := range f {
+		gd[i] = b.groupDelay(2*math.Pi*f[i]/Fs) / Fs
+	}
+	return gd
+}
+
+// groupDelay returns the group delay in samples at normalized frequency w (rad/sample).
+// For a polynomial c(z^-1) = sum(c_k * z^-k) the group delay contribution is
+//  tau_c(w) = real( sum(k * c_k * e^{-jkw}) / sum(c_k * e^{-jkw}) )
+// and the filter group delay is tau_b(w) - tau_a(w).
+func (b *blt) groupDelay(w float64) float64 {
+	tau := func(c0, c1, c2 float64) float64 {
+		z1 := cmplx.Rect(1, -w)
+		z2 := z1 * z1
+		num := complex(c1, 0)*z1 + complex(2*c2, 0)*z2
+		den := complex(c0, 0) + complex(c1, 0)*z1 + complex(c2, 0)*z2
+		return real(num / den)
+	}
+	return tau(b.b0d, b.b1d, b.b2d) - tau(1, b.a1d, b.a2d)
+}
@@ -2,10 +2,17 @@ package biquad
 
 import (
 	"math"
+	"math/cmplx"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/font"
 	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/plotutil"
+	"gonum.org/v1/plot/vg/draw"
 )
 
 func (b blt) getH() func(z complex128) complex128 {
@@ -16,49 +23,207 @@ func (b blt) getH() func(z complex128) complex128 {
 	}
 }
 
-// Plot the BodePlot of a discrete time transfer function H and the sampling period.
-func plotBode(plotname string, ts float64, wmax float64, H func(z complex128) complex128) {
-	p := plot.New()
-	xy := noNaN{bodeFunc{H: H, ts: ts, wmax: wmax}}
-	bode, err := plotter.NewLine(xy)
-	if err != nil {
-		panic(err)
-	}
-	p.Title.Text = "Bode Plot"
-	p.Y.Label.Text = "real( H( e^{jwT} ) )"
-	p.X.Label.Text = "Frequency [rad/s]"
-	p.Add(bode)
-	p.X.Scale = plot.LogScale{}
-	p.Save(30*font.Centimeter, 20*font.Centimeter, plotname)
+// Bode is a Bode plot of the frequency response of one or more filters
+// evaluated on the unit circle, z = e^{j*2*pi*f/Fs}. It consists of a magnitude
+// panel in decibels and a phase panel in degrees against frequency in Hz on
+// a logarithmic axis up to the Nyquist frequency.
+// Resonances of filters exposing their poles (see ZPK) are added to the
+// evaluated frequencies so narrow peaks are not missed.
+// Frequencies where the response is zero or infinite (zeros or poles on
+// the unit circle) are left out, which shows up as a gap in the plotted line.
+type Bode struct {
+	// Fs is the sampling frequency.
+	Fs float64
+	// Fmin is the lowest frequency plotted. If zero it is set
+	// three decades below the Nyquist frequency.
+	Fmin float64
+	// N is the number of frequencies evaluated. If zero 500 frequencies are used.
+	N int
+	// Title of the plot.
+	Title string
+
+	names   []string
+	filters []Responder
 }
 
-const nBode = 400
+// NewBode returns an empty Bode plot for filters with sampling frequency Fs.
+func NewBode(Fs float64) *Bode {
+	return &Bode{Fs: Fs, Title: "Bode Plot"}
+}
 
-// Plot discrete time systems from H(z)
-type bodeFunc struct {
-	H    func(complex128) complex128
-	ts   float64 // sampling period
-	wmax float64 // max frequency graphed
+// Add adds the frequency response of a filter to the plot. name is shown in the legend.
+func (b *Bode) Add(name string, r Responder) {
+	b.names = append(b.names, name)
+	b.filters = append(b.filters, r)
 }
 
-func (b bodeFunc) Len() int { return nBode }
+// Plots returns the magnitude and phase panels of the Bode plot.
+func (b *Bode) Plots() (mag, phase *plot.Plot, err error) {
+	switch {
+	case b.Fs <= 0 || b.Fmin < 0:
+		return nil, nil, ErrBadFreq
+	case b.Fmin >= b.Fs/2:
+		return nil, nil, ErrAboveNyquist
+	}
+	mag, phase = plot.New(), plot.New()
+	mag.Title.Text = b.Title
+	mag.Y.Label.Text = "Magnitude [dB]"
+	phase.Y.Label.Text = "Phase [deg]"
+	phase.X.Label.Text = "Frequency [Hz]"
+	grid := b.freqs()
+	for i, r := range b.filters {
+		f := b.withResonances(grid, r)
+		h := r.Response(b.Fs, f)
+		db := MagnitudeDB(h)
+		deg := phaseDeg(h)
+		color := plotutil.Color(i)
+		for j, p := range []*plot.Plot{mag, phase} {
+			y := db
+			if j == 1 {
+				y = deg
+			}
+			lines, err := segments(f, y)
+			if err != nil {
+				return nil, nil, err
+			}
+			for k, l := range lines {
+				l.Color = color
+				p.Add(l)
+				if j == 0 && k == 0 {
+					mag.Legend.Add(b.names[i], l)
+				}
+			}
+		}
+	}
+	for _, p := range []*plot.Plot{mag, phase} {
+		p.X.Scale = plot.LogScale{}
+		p.X.Tick.Marker = plot.LogTicks{}
+		p.X.Min, p.X.Max = grid[0], grid[len(grid)-1]
+		p.Add(plotter.NewGrid())
+	}
+	mag.Legend.Top = true
+	return mag, phase, nil
+}
 
-// z -> e^{jwT} == cos(wT) + j*sin(wT)
-func (b bodeFunc) XY(i int) (x, y float64) {
-	w := float64(i) + 0.1
-	x = math.Pow(w, math.Log(b.wmax)/math.Log(float64(nBode)))
-	y = real(b.H(complex(math.Cos(x*b.ts), math.Sin(x*b.ts))))
-	return x, y
+// Save saves the Bode plot to a file with magnitude and phase panels stacked
+// vertically. The file format is chosen by the extension of the file name
+// (see gonum.org/v1/plot.Plot.Save for the supported formats).
+func (b *Bode) Save(w, h font.Length, filename string) error {
+	mag, phase, err := b.Plots()
+	if err != nil {
+		return err
+	}
+	format := strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
+	c, err := draw.NewFormattedCanvas(w, h, format)
+	if err != nil {
+		return err
+	}
+	plots := [][]*plot.Plot{{mag}, {phase}}
+	canvases := plot.Align(plots, draw.Tiles{Rows: 2, Cols: 1, PadY: font.Centimeter / 2}, draw.New(c))
+	mag.Draw(canvases[0][0])
+	phase.Draw(canvases[1][0])
+	fp, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if _, err = c.WriteTo(fp); err != nil {
+		fp.Close()
+		return err
+	}
+	return fp.Close()
 }
 
-type noNaN struct {
-	plotter.XYer
+// freqs returns the logarithmically spaced frequencies of the plot.
+func (b *Bode) freqs() []float64 {
+	n := b.N
+	if n < 2 {
+		n = 500
+	}
+	nyq := b.Fs / 2
+	fmin := b.Fmin
+	if fmin == 0 {
+		fmin = nyq / 1000
+	}
+	f := make([]float64, n)
+	for i := range f {
+		f[i] = fmin * math.Pow(nyq/fmin, float64(i)/float64(n-1))
+	}
+	f[n-1] = nyq
+	return f
+}
+
+// withResonances returns the frequency grid f with the frequencies of the poles
+// of r near the unit circle added in order.
+func (b *Bode) withResonances(f []float64, r Responder) []float64 {
+	z, ok := r.(interface{ ZPK() ZPK })
+	if !ok {
+		return f
+	}
+	f = append([]float64{}, f...)
+	for _, p := range z.ZPK().P {
+		fp := math.Abs(cmplx.Phase(p)) * b.Fs / (2 * math.Pi)
+		if cmplx.Abs(p) < 0.9 || fp <= f[0] || fp >= f[len(f)-1] {
+			continue
+		}
+		i := sort.SearchFloat64s(f, fp)
+		f = append(f[:i], append([]float64{fp}, f[i:]...)...)
+	}
+	return f
+}
+
+// phaseDeg returns the unwrapped phase in degrees of the frequency response h.
+// Phase is undefined where h is zero or not finite, these values are NaN.
+func phaseDeg(h []complex128) []float64 {
+	var valid []complex128
+	for _, v := range h {
+		if finiteNonZero(v) {
+			valid = append(valid, v)
+		}
+	}
+	ph := Phase(valid)
+	deg := make([]float64, len(h))
+	j := 0
+	for i, v := range h {
+		if !finiteNonZero(v) {
+			deg[i] = math.NaN()
+			continue
+		}
+		deg[i] = ph[j] * 180 / math.Pi
+		j++
+	}
+	return deg
 }
 
-func (n noNaN) XY(i int) (x, y float64) {
-	x, y = n.XYer.XY(i)
-	if y != y {
-		y = 0.1
+func finiteNonZero(v complex128) bool {
+	return v != 0 && !cmplx.IsInf(v) && !cmplx.IsNaN(v)
+}
+
+// segments splits the curve (x, y) into lines of consecutive finite values.
+func segments(x, y []float64) ([]*plotter.Line, error) {
+	var lines []*plotter.Line
+	var xy plotter.XYs
+	flush := func() error {
+		if len(xy) > 0 {
+			l, err := plotter.NewLine(xy)
+			if err != nil {
+				return err
+			}
+			lines = append(lines, l)
+		}
+		xy = nil
+		return nil
+	}
+	for i := range x {
+		if math.IsNaN(y[i]) || math.IsInf(y[i], 0) {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		xy = append(xy, plotter.XY{X: x[i], Y: y[i]})
+	}
+	if err := flush(); err != nil {
+		return nil, err
 	}
-	return x, y
+	return lines, nil
 }
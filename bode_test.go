@@ -2,52 +2,93 @@ package biquad
 
 import (
 	"math"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
+
+	"gonum.org/v1/plot/font"
 )
 
 func TestBLTBode(t *testing.T) {
 	const (
 		fs = 100.
-		ts = 1 / fs
 		f0 = 4
-		w0 = math.Pi * 2 * f0
 	)
 	lp, err := NewNotch(fs, f0, 1)
 	if err != nil {
 		t.Fatal(err)
 	}
-	H := lp.getH()
-	plotBode("notch_f0=4.png", ts, f0*60, H)
+	b := NewBode(fs)
+	b.Add("notch f0=4Hz", lp)
+	saveBode(t, b, "notch_f0=4.png")
 }
 
 func TestButterBode(t *testing.T) {
 	//https://www.robots.ox.ac.uk/~sjrob/Teaching/SP/l6.pdf
 	const (
 		fs = 1e4
-		ts = 1 / fs
 		f0 = 2e3
-		w0 = math.Pi * 2 * f0
 	)
 	// Design a digital low-pass Butterworth filter with a 3dB cut-off frequency of 2kHz
 	// and minimum attenuation of 30dB at 4.25kHz for a sampling rate of 10kHz
-	b, err := NewButterworthLP(fs, f0)
+	b := NewBode(fs)
+	for _, n := range []int{2, 4, 8} {
+		lp, err := NewButterworthLPN(fs, f0, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Add("butterworth n="+strconv.Itoa(n), lp)
+	}
+	mag, phase, err := b.Plots()
 	if err != nil {
 		t.Fatal(err)
 	}
-	H := b.getH()
-	plotBode("butter_wc=2k.png", ts, f0*60, H)
+	if math.Abs(mag.X.Max-fs/2) > 1e-9 || math.Abs(phase.X.Min-fs/2000) > 1e-9 {
+		t.Errorf("expected frequency axis up to Nyquist, got [%g, %g]", phase.X.Min, mag.X.Max)
+	}
+	saveBode(t, b, "butter_wc=2k.svg")
 }
 
 func TestChebyBode(t *testing.T) {
 	const (
 		fs = 1e4
-		ts = 1 / fs
 		f0 = 2e2
 	)
-	b, err := newChebyshevLPType1(fs, f0, 0.1)
+	b := NewBode(fs)
+	b.Fmin = 1
+	lp, err := NewChebyshev1LP(fs, f0, 1, 2)
 	if err != nil {
 		t.Fatal(err)
 	}
-	H := b.getH()
-	plotBode("cheby1_wc=2k.png", ts, f0*60, H)
+	// Poles very near the unit circle.
+	peak, err := NewPeakingEQQ(fs, f0, 500, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Add("chebyshev I", lp)
+	b.Add("peaking Q=500", peak)
+	var max float64
+	for _, v := range peak.MagnitudeDB(fs, b.withResonances(b.freqs(), peak)) {
+		max = math.Max(max, v)
+	}
+	if math.Abs(max-12) > 1e-3 {
+		t.Errorf("expected narrow 12dB peak to be evaluated, got maximum of %gdB", max)
+	}
+	saveBode(t, b, "cheby1_wc=2k.png")
+	b.Fmin = fs
+	if _, _, err := b.Plots(); err != ErrAboveNyquist {
+		t.Errorf("expected ErrAboveNyquist, got %v", err)
+	}
+}
+
+func saveBode(t *testing.T, b *Bode, name string) {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), name)
+	if err := b.Save(30*font.Centimeter, 20*font.Centimeter, filename); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(filename); err != nil || info.Size() == 0 {
+		t.Fatalf("expected plot file to be written: %v", err)
+	}
 }
@@ -2,6 +2,7 @@ package biquad
 
 import (
 	"math"
+	"math/cmplx"
 )
 
 // Butterworth filter. May represent Lowpass and other
@@ -24,15 +25,15 @@ type ButterWorth struct {
 // where td = tan(wc_d * Ts / 2)
 // Note: (2/Ts)^2 is canceled out.
 // We now multiply denominator and numerator by (1+z^{-1})^2
-//  H(z) = td*(1 + 2*z^{-1} + z^{-2}) / ( (1-z^{-1})^2 + td*sqrt(2)*(1-z^{-1})*(1+z^{-1}) + td^2*(1+z^{-1})^2
+//  H(z) = td^2*(1 + 2*z^{-1} + z^{-2}) / ( (1-z^{-1})^2 + td*sqrt(2)*(1-z^{-1})*(1+z^{-1}) + td^2*(1+z^{-1})^2
 //  H(z) denominator = 1 - 2z^{-1} + z^{-2} + td*sqrt(2)*(1 - z^{-2}) + td^2 * (1+2z^{-1} + z^{-2})
 //   grouping...     = 1 + td*sqrt(2) + td^2 + z^{-1}*( -2 + 2*td^2 )  + z^{-2} * (1 - td*sqrt(2) + td^2)
 // The coefficients for BLT application are now readily available from the work above.
 
 // NewButterworthLP creates a low pass Butterworth filter from
 //  Fs: sampling frequency
-//  fc: cutoff frequency of approximately -3dB gain
-// Not guaranteed to have peak unity gain.
+//  fc: cutoff frequency of -3dB gain
+// The filter has unity gain at DC.
 func NewButterworthLP(Fs, fc float64) (*ButterWorth, error) {
 	switch {
 	case fc >= Fs:
@@ -44,8 +45,8 @@ func NewButterworthLP(Fs, fc float64) (*ButterWorth, error) {
 	wc := 2 * math.Pi * fc
 	td := math.Tan(wc / (2 * Fs))
 	var (
-		b0 = td
-		b1 = 2 * td
+		b0 = td * td
+		b1 = 2 * td * td
 		b2 = b0
 		a0 = 1 + td*math.Sqrt2 + td*td
 		a1 = -2 + 2*td*td
@@ -105,3 +106,115 @@ func NewButterworthHP(Fs, fc float64) (*ButterWorth, error) {
 		blt: newBLT(a0, a1, a2, b0, b1, b2),
 	}, nil
 }
+
+// The general Butterworth filter of order n is obtained from the poles of Bn(s),
+// which are evenly spaced on the left half of the unit circle (see ButterworthPrototype). Conjugate pole pairs
+// form the second order sections and odd orders have an additional first order
+// section for the real pole at s = -1. Each section is then discretized with
+// the pre-warped bilinear transformation.
+
+// NewButterworthLPN creates a low pass Butterworth filter of arbitrary order from
+//  Fs: sampling frequency
+//  fc: cutoff frequency of -3dB gain
+//  n: filter order
+// The filter is implemented as a cascade of second order sections and
+// has unity gain at DC.
+func NewButterworthLPN(Fs, fc float64, n int) (*SOS, error) {
+	switch {
+	case n < 1:
+		return nil, ErrBadOrder
+	case fc <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	case fc >= Fs/2:
+		return nil, ErrAboveNyquist
+	}
+	wc := prewarp(Fs, fc)
+	return butterworthPrototype(n).LPToLP(wc).bilinear(Fs).sos(), nil
+}
+
+// NewButterworthHPN creates a high pass Butterworth filter of arbitrary order from
+//  Fs: sampling frequency
+//  fc: cutoff frequency of -3dB gain
+//  n: filter order
+// The filter is implemented as a cascade of second order sections and
+// has unity gain at the Nyquist frequency.
+func NewButterworthHPN(Fs, fc float64, n int) (*SOS, error) {
+	switch {
+	case n < 1:
+		return nil, ErrBadOrder
+	case fc <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	case fc >= Fs/2:
+		return nil, ErrAboveNyquist
+	}
+	wc := prewarp(Fs, fc)
+	return butterworthPrototype(n).LPToHP(wc).bilinear(Fs).sos(), nil
+}
+
+// NewButterworthBPN creates a band pass Butterworth filter of arbitrary order from
+//  Fs: sampling frequency
+//  f1: lower cutoff frequency of -3dB gain
+//  f2: upper cutoff frequency of -3dB gain
+//  n: order of the low pass prototype. The resulting filter is of order 2*n.
+// The filter is implemented as a cascade of second order sections and
+// has unity gain at the geometric center frequency of the pre-warped band edges.
+func NewButterworthBPN(Fs, f1, f2 float64, n int) (*SOS, error) {
+	switch {
+	case n < 1:
+		return nil, ErrBadOrder
+	case f1 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	case f2 <= f1:
+		return nil, ErrNegBandwidth
+	case f2 >= Fs/2:
+		return nil, ErrAboveNyquist
+	}
+	w1, w2 := prewarp(Fs, f1), prewarp(Fs, f2)
+	return butterworthPrototype(n).LPToBP(math.Sqrt(w1*w2), w2-w1).bilinear(Fs).sos(), nil
+}
+
+// NewButterworthBSN creates a band stop Butterworth filter of arbitrary order from
+//  Fs: sampling frequency
+//  f1: lower cutoff frequency of -3dB gain
+//  f2: upper cutoff frequency of -3dB gain
+//  n: order of the low pass prototype. The resulting filter is of order 2*n.
+// The filter is implemented as a cascade of second order sections and
+// has unity gain at DC and at the Nyquist frequency.
+func NewButterworthBSN(Fs, f1, f2 float64, n int) (*SOS, error) {
+	switch {
+	case n < 1:
+		return nil, ErrBadOrder
+	case f1 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	case f2 <= f1:
+		return nil, ErrNegBandwidth
+	case f2 >= Fs/2:
+		return nil, ErrAboveNyquist
+	}
+	w1, w2 := prewarp(Fs, f1), prewarp(Fs, f2)
+	return butterworthPrototype(n).LPToBS(math.Sqrt(w1*w2), w2-w1).bilinear(Fs).sos(), nil
+}
+
+// ButterworthPrototype returns the analog low pass Butterworth prototype
+// of order n with a cutoff frequency of 1 rad/s. The poles are
+// the roots of Bn(s) which lie on the left half of the unit circle:
+//  p_k = exp(j*pi*(2*k+n+1)/(2*n))   k = 0, 1, ..., n-1
+// Invalid parameters return ErrBadOrder.
+func ButterworthPrototype(n int) (ZPK, error) {
+	if n < 1 {
+		return ZPK{}, ErrBadOrder
+	}
+	return butterworthPrototype(n), nil
+}
+
+// butterworthPrototype is ButterworthPrototype without parameter validation.
+func butterworthPrototype(n int) ZPK {
+	p := make([]complex128, n)
+	for k := range p {
+		p[k] = cmplx.Rect(1, math.Pi*float64(2*k+n+1)/float64(2*n))
+		if 2*k+1 == n {
+			p[k] = -1 // Avoid rounding on the real pole of odd order filters.
+		}
+	}
+	return ZPK{P: p, K: 1}
+}
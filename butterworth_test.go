@@ -0,0 +1,109 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestButterworthN(t *testing.T) {
+	const (
+		fs   = 1e3
+		fc   = 100.
+		f1   = 50.
+		f2   = 200.
+		tol  = 1e-9
+		db3  = -3.0102999566398125 // 10*log10(1/2)
+		maxN = 8
+	)
+	dB := func(s *SOS, f float64) float64 {
+		return 20 * math.Log10(cmplx.Abs(s.getH()(cmplx.Rect(1, 2*math.Pi*f/fs))))
+	}
+	for n := 1; n <= maxN; n++ {
+		lp, err := NewButterworthLPN(fs, fc, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hp, err := NewButterworthHPN(fs, fc, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bp, err := NewButterworthBPN(fs, f1, f2, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bs, err := NewButterworthBSN(fs, f1, f2, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := lp.Len(), (n+1)/2; got != want {
+			t.Errorf("order %d: expected %d sections, got %d", n, want, got)
+		}
+		for _, test := range []struct {
+			name string
+			s    *SOS
+			f    float64
+			want float64
+		}{
+			{"LP DC", lp, 0, 0},
+			{"LP fc", lp, fc, db3},
+			{"HP Nyquist", hp, fs / 2, 0},
+			{"HP fc", hp, fc, db3},
+			{"BP f1", bp, f1, db3},
+			{"BP f2", bp, f2, db3},
+			{"BS DC", bs, 0, 0},
+			{"BS Nyquist", bs, fs / 2, 0},
+			{"BS f1", bs, f1, db3},
+			{"BS f2", bs, f2, db3},
+		} {
+			if got := dB(test.s, test.f); math.Abs(got-test.want) > tol {
+				t.Errorf("order %d %s: expected %gdB, got %gdB", n, test.name, test.want, got)
+			}
+		}
+		// Roll-off is steeper with higher orders: -20*n dB/decade well above fc.
+		if got := dB(lp, 400) - dB(lp, 200); got > -6*float64(n)*0.5 {
+			t.Errorf("order %d: expected steeper roll-off, got %gdB/octave", n, got)
+		}
+	}
+	if _, err := NewButterworthLPN(fs, fc, 0); err != ErrBadOrder {
+		t.Errorf("expected ErrBadOrder, got %v", err)
+	}
+	if _, err := NewButterworthLPN(fs, fs/2, 4); err != ErrAboveNyquist {
+		t.Errorf("expected ErrAboveNyquist, got %v", err)
+	}
+}
+
+func TestSOSProcess(t *testing.T) {
+	const fs = 1e3
+	lp, err := NewButterworthLPN(fs, 50, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A step input should settle at the DC gain of the filter.
+	var y float64
+	for i := 0; i < 1000; i++ {
+		lp.DiscreteProcess(1)
+		y = lp.YNext()
+	}
+	if math.Abs(y-1) > 1e-9 {
+		t.Errorf("expected step response to settle at 1, got %g", y)
+	}
+}
+
+func TestButterworthLPGain(t *testing.T) {
+	const (
+		fs = 1e3
+		fc = 100.
+	)
+	lp, err := NewButterworthLP(fs, fc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	H := lp.getH()
+	if got := cmplx.Abs(H(1)); math.Abs(got-1) > 1e-12 {
+		t.Errorf("expected unity DC gain, got %g", got)
+	}
+	if got := cmplx.Abs(H(cmplx.Rect(1, 2*math.Pi*fc/fs))); math.Abs(got-math.Sqrt(0.5)) > 1e-12 {
+		t.Errorf("expected gain of 1/sqrt(2) at fc, got %g", got)
+	}
+}
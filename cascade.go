@@ -0,0 +1,110 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Cascade is an ordered chain of recursive filters. Each sample passed
+// to the cascade is processed by the first filter and its output is passed on
+// to the next, so the result is the output of the last filter.
+// Cascade implements RecursiveFilter so cascades may be nested.
+type Cascade struct {
+	filters []RecursiveFilter
+}
+
+// NewCascade creates a cascade of filters. Samples flow from the
+// first to the last filter.
+func NewCascade(filters ...RecursiveFilter) *Cascade {
+	c := &Cascade{}
+	c.Append(filters...)
+	return c
+}
+
+// Append adds filters to the end of the cascade.
+func (c *Cascade) Append(filters ...RecursiveFilter) {
+	c.filters = append(c.filters, filters...)
+}
+
+// Len returns the number of filters in the cascade.
+func (c *Cascade) Len() int { return len(c.filters) }
+
+// DiscreteProcess takes in the next signal data point
+// and processes it through all filters in order.
+func (c *Cascade) DiscreteProcess(x float64) {
+	for _, f := range c.filters {
+		f.DiscreteProcess(x)
+		x = f.YNext()
+	}
+}
+
+// YNext returns the last result of the cascade given by
+// DiscreteProcess. It is the output of the last filter.
+func (c *Cascade) YNext() (y float64) {
+	if len(c.filters) == 0 {
+		return 0
+	}
+	return c.filters[len(c.filters)-1].YNext()
+}
+
+// Filter processes a digital signal through the cascade and returns the
+// filtered result. Unlike the Filter method of single filters, the state
+// of the filters is not initialized from the signal: processing
+// continues from the current state of each filter. The length of the data must be greater than 2.
+func (c *Cascade) Filter(signal Signal) (Signal, error) {
+	N := signal.Len()
+	if N < 3 {
+		return nil, ErrShortXY
+	}
+	fval := make([]float64, N)
+	for i := 0; i < N; i++ {
+		_, x := signal.XY(i)
+		c.DiscreteProcess(x)
+		fval[i] = c.YNext()
+	}
+	return filtered{
+		Signal: signal,
+		fval:   fval,
+	}, nil
+}
+
+// transferer is implemented by filters with a known transfer function H(z).
+type transferer interface {
+	getH() func(z complex128) complex128
+}
+
+// transferFunc returns the transfer function of a filter. Filters which only
+// implement Responder are evaluated on the unit circle at the angle of z.
+func transferFunc(f RecursiveFilter) (H func(z complex128) complex128, ok bool) {
+	switch t := f.(type) {
+	case transferer:
+		return t.getH(), true
+	case Responder:
+		return func(z complex128) complex128 {
+			// With Fs=2*pi the frequency in Hz equals the angular frequency in rad/sample.
+			return t.Response(2*math.Pi, []float64{cmplx.Phase(z)})[0]
+		}, true
+	}
+	return nil, false
+}
+
+// getH returns the transfer function of the cascade, the product
+// of the transfer functions of its filters. If a filter does not
+// implement Responder the result is NaN.
+func (c Cascade) getH() func(z complex128) complex128 {
+	hs := make([]func(complex128) complex128, len(c.filters))
+	for i, f := range c.filters {
+		H, ok := transferFunc(f)
+		if !ok {
+			return func(complex128) complex128 { return cmplx.NaN() }
+		}
+		hs[i] = H
+	}
+	return func(z complex128) complex128 {
+		h := complex(1, 0)
+		for i := range hs {
+			h *= hs[i](z)
+		}
+		return h
+	}
+}
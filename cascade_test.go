@@ -0,0 +1,72 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestCascade(t *testing.T) {
+	const (
+		fs = 1e3
+		N  = 500
+	)
+	// Notch, low pass and DC blocker chain.
+	newChain := func() (*Notch, *SOS, *HighPass) {
+		notch, err := NewNotchQ(fs, 50, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lp, err := NewButterworthLPN(fs, 200, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dc, err := NewHighPassQ(fs, 0.5, math.Sqrt2/2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return notch, lp, dc
+	}
+	notch, lp, dc := newChain()
+	c := NewCascade(notch, NewCascade(lp), dc)
+	data := make([]float64, N)
+	for i := range data {
+		ti := float64(i) / fs
+		data[i] = 1 + math.Sin(2*math.Pi*50*ti) + math.Sin(2*math.Pi*10*ti) + 0.1*math.Sin(2*math.Pi*400*ti)
+	}
+	got, err := c.Filter(MakeSignal(fs, data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	notch2, lp2, dc2 := newChain()
+	for i := range data {
+		var y float64
+		for _, f := range []RecursiveFilter{notch2, lp2, dc2} {
+			f.DiscreteProcess(data[i])
+			y = f.YNext()
+			data[i] = y
+		}
+		if _, gy := got.XY(i); gy != y {
+			t.Fatalf("sample %d: expected %g, got %g", i, y, gy)
+		}
+	}
+
+	// The response of the cascade is the product of the responses of its filters.
+	freqs := []float64{1, 10, 50, 100, 400}
+	h := c.Response(fs, freqs)
+	hn, hl, hd := notch.Response(fs, freqs), lp.Response(fs, freqs), dc.Response(fs, freqs)
+	for i, f := range freqs {
+		if want := hn[i] * hl[i] * hd[i]; cmplx.Abs(h[i]-want) > 1e-12 {
+			t.Errorf("response at %gHz: expected %v, got %v", f, want, h[i])
+		}
+	}
+	if got := NewCascade(opaqueFilter{}).Response(fs, []float64{0})[0]; !cmplx.IsNaN(got) {
+		t.Errorf("expected NaN response for filter without transfer function, got %v", got)
+	}
+}
+
+// opaqueFilter is a RecursiveFilter with unknown transfer function.
+type opaqueFilter struct{ y float64 }
+
+func (o opaqueFilter) DiscreteProcess(x float64) {}
+func (o opaqueFilter) YNext() float64            { return o.y }
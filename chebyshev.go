@@ -6,63 +6,145 @@ import (
 
 // Chebyshev filter. May represent Lowpass and other
 // Chebyshev filter implementations.
+//
+// Deprecated: Chebyshev is limited to a single second order section and has no
+// exported constructor. Use NewChebyshev1LP and the related constructors, which
+// return a cascade of second order sections of arbitrary order.
 type Chebyshev struct {
 	blt
 }
 
 // From wikipedia:
-// Chebyshev type I Transfer function:
-//  H(s) = (1/(2*e)) / (  s^2 - s*(sp_1+sp_2) + sp_1*sp_2)
-// where sp_m = j*cosh(asinh(1/e)/n)*cos(theta_m) - sinh(asinh(1/e)/n)*sin(theta_m)
-// where theta_m = pi*(2*m - 1) / (2*n)
-// We apply bilinear transformation as seen in butterworth file (and take into account non unity frequncy cutoff wc)
-//  H(z) = (wc^2/ (2*e) * (1+2z^-1+z^-2) / (1-2z^{-1}+z^{-2} - wc*(sp1+sp2)*(1-z^{-2}) +wc^2*sp1*sp2*(1+2*z^{-1}+z^{-2}))
-// where wc is the pre-warped (analog) cutoff frequency.
-//  H(z)_denominator = 1-wc(sp1+sp2)+wc^2*sp1*sp2 + z^{-1}*(-2+2*wc^2*sp1*sp2) + z^{-2}*(1+wc*(sp1+sp2)+wc^2*sp1*sp2)
-// We may now construct the BLT after applying frequency warp correction.
+// Chebyshev type I filters have equiripple gain in the passband and
+// a monotonic stopband. The gain of the normalized low pass filter is
+//  |H(jw)|^2 = 1 / (1 + e^2 * Tn(w)^2)
+// where Tn is the Chebyshev polynomial of order n and e is the ripple factor,
+// related to the passband ripple rp in decibels by
+//  e = sqrt(10^(rp/10) - 1)
+// The poles of the transfer function lie on an ellipse on the left half plane
+//  sp_m = -sinh(asinh(1/e)/n)*sin(theta_m) + j*cosh(asinh(1/e)/n)*cos(theta_m)
+// where theta_m = pi*(2*m - 1) / (2*n) for m = 1, 2, ..., n.
+// The gain is chosen so the filter has unity gain at DC for odd orders and
+// 1/sqrt(1+e^2) (the bottom of the ripple) at DC for even orders.
+// Conjugate pole pairs are then discretized section by section with the pre-warped
+// bilinear transformation as done with Butterworth filters.
 
-// NewChebyshevLPType1 creates a low pass Chebyshev Type I filter from
+// NewChebyshev1LP creates a low pass Chebyshev Type I filter from
 //  Fs: sampling frequency
-//  fh: -3dB attenuation frequency. This frequency is lower than the cutoff in Chebyshev lowpass filters.
-// Not guaranteed to have peak unity gain.
-func newChebyshevLPType1(Fs, fh, e float64) (*Chebyshev, error) {
-	const n = 2. // Filter order.
+//  fc: passband edge frequency, where the gain last reaches -rp dB.
+//  rp: peak to peak passband ripple in decibels.
+//  n: filter order
+// The -3dB frequency of the filter is above fc for ripples under 3dB.
+func NewChebyshev1LP(Fs, fc, rp float64, n int) (*SOS, error) {
 	switch {
-	case fh >= Fs:
-		return nil, ErrBadWorkingFreq
-	case fh <= 0 || Fs <= 0:
+	case n < 1:
+		return nil, ErrBadOrder
+	case rp <= 0:
+		return nil, ErrBadRipple
+	case fc <= 0 || Fs <= 0:
 		return nil, ErrBadFreq
+	case fc >= Fs/2:
+		return nil, ErrAboveNyquist
 	}
-	// The -3dB stopband start wh is related to wc by:
-	// wh = wc * cosh(acosh(1/e)/n)
-	// thus wc = wh / cosh(acosh(1/e)/n)
-	wc := 2 * math.Pi * fh / math.Cosh(math.Acosh(1/e)/n)
-	td := math.Tan(wc / (2 * Fs)) // wc_a = 2/Ts * tan(wc_d * Ts / 2)
-	sp1 := sparametric1(2, 1, true, e)
-	sp2 := sparametric1(2, 2, true, e)
-	tdc := complex(td, 0)
-	var ( // TODO fix whatever dont work here
-		b0 = td * td / (2 * e)
-		b1 = 2 * b0
-		b2 = b0
-		a0 = real(1 - tdc*sp1 + sp2 + tdc*tdc*sp1*sp2)
-		a1 = real(-2 + 2*tdc*tdc*sp1*sp2)
-		a2 = real(1 + tdc*(sp1+sp2) + tdc*tdc*sp1*sp2)
-	)
-	return &Chebyshev{
-		blt: newBLT(a0, a1, a2, b0, b1, b2), // Do I need a new complex valued BLT type?
-	}, nil
+	wc := prewarp(Fs, fc)
+	return chebyshev1Prototype(n, rp).LPToLP(wc).bilinear(Fs).sos(), nil
 }
 
-// calculate parametric pole of chebyshev type 1 transfer function.
-func sparametric1(n, m int, neg bool, e float64) complex128 {
+// NewChebyshev1HP creates a high pass Chebyshev Type I filter from
+//  Fs: sampling frequency
+//  fc: passband edge frequency, where the gain first reaches -rp dB.
+//  rp: peak to peak passband ripple in decibels.
+//  n: filter order
+func NewChebyshev1HP(Fs, fc, rp float64, n int) (*SOS, error) {
+	switch {
+	case n < 1:
+		return nil, ErrBadOrder
+	case rp <= 0:
+		return nil, ErrBadRipple
+	case fc <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	case fc >= Fs/2:
+		return nil, ErrAboveNyquist
+	}
+	wc := prewarp(Fs, fc)
+	return chebyshev1Prototype(n, rp).LPToHP(wc).bilinear(Fs).sos(), nil
+}
+
+// NewChebyshev1BP creates a band pass Chebyshev Type I filter from
+//  Fs: sampling frequency
+//  f1: lower passband edge frequency, where the gain first reaches -rp dB.
+//  f2: upper passband edge frequency, where the gain last reaches -rp dB.
+//  rp: peak to peak passband ripple in decibels.
+//  n: order of the low pass prototype. The resulting filter is of order 2*n.
+func NewChebyshev1BP(Fs, f1, f2, rp float64, n int) (*SOS, error) {
+	switch {
+	case n < 1:
+		return nil, ErrBadOrder
+	case rp <= 0:
+		return nil, ErrBadRipple
+	case f1 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	case f2 <= f1:
+		return nil, ErrNegBandwidth
+	case f2 >= Fs/2:
+		return nil, ErrAboveNyquist
+	}
+	w1, w2 := prewarp(Fs, f1), prewarp(Fs, f2)
+	return chebyshev1Prototype(n, rp).LPToBP(math.Sqrt(w1*w2), w2-w1).bilinear(Fs).sos(), nil
+}
+
+// NewChebyshev1BS creates a band stop Chebyshev Type I filter from
+//  Fs: sampling frequency
+//  f1: lower passband edge frequency, where the gain last reaches -rp dB.
+//  f2: upper passband edge frequency, where the gain first reaches -rp dB.
+//  rp: peak to peak passband ripple in decibels.
+//  n: order of the low pass prototype. The resulting filter is of order 2*n.
+func NewChebyshev1BS(Fs, f1, f2, rp float64, n int) (*SOS, error) {
+	switch {
+	case n < 1:
+		return nil, ErrBadOrder
+	case rp <= 0:
+		return nil, ErrBadRipple
+	case f1 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	case f2 <= f1:
+		return nil, ErrNegBandwidth
+	case f2 >= Fs/2:
+		return nil, ErrAboveNyquist
+	}
+	w1, w2 := prewarp(Fs, f1), prewarp(Fs, f2)
+	return chebyshev1Prototype(n, rp).LPToBS(math.Sqrt(w1*w2), w2-w1).bilinear(Fs).sos(), nil
+}
+
+// Chebyshev1Prototype returns the analog low pass Chebyshev Type I prototype
+// of order n and passband ripple rp (dB) with passband edge at 1 rad/s.
+// Invalid parameters return ErrBadOrder or ErrBadRipple.
+func Chebyshev1Prototype(n int, rp float64) (ZPK, error) {
+	switch {
+	case n < 1:
+		return ZPK{}, ErrBadOrder
+	case rp <= 0:
+		return ZPK{}, ErrBadRipple
+	}
+	return chebyshev1Prototype(n, rp), nil
+}
+
+// chebyshev1Prototype is Chebyshev1Prototype without parameter validation.
+func chebyshev1Prototype(n int, rp float64) ZPK {
+	e := math.Sqrt(math.Pow(10, rp/10) - 1)
 	N := float64(n)
-	M := float64(m)
-	tm := math.Pi * (2*M - 1) / (2 * N)
-	imagpart := math.Cosh(math.Asinh(1/e)/N) * math.Cos(tm)
-	realpart := math.Sinh(math.Asinh(1/e)/N) * math.Sin(tm)
-	if neg {
-		realpart *= -1
+	mu := math.Asinh(1/e) / N
+	p := make([]complex128, n)
+	for m := range p {
+		theta := math.Pi * float64(2*m+1) / (2 * N)
+		p[m] = complex(-math.Sinh(mu)*math.Sin(theta), math.Cosh(mu)*math.Cos(theta))
+		if 2*m+1 == n {
+			p[m] = complex(-math.Sinh(mu), 0) // Exactly real pole for odd orders.
+		}
+	}
+	k := real(prodNeg(p))
+	if n%2 == 0 {
+		k /= math.Sqrt(1 + e*e)
 	}
-	return complex(realpart, imagpart)
+	return ZPK{P: p, K: k}
 }
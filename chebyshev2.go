@@ -0,0 +1,137 @@
+package biquad
+
+import (
+	"math"
+)
+
+// Chebyshev type II (inverse Chebyshev) filters have a maximally flat passband and
+// an equiripple stopband. The gain of the normalized low pass filter is
+//  |H(jw)|^2 = 1 / (1 + 1/(e^2 * Tn(1/w)^2))
+// where Tn is the Chebyshev polynomial of order n and e is related to the
+// minimum stopband attenuation rs in decibels by
+//  e = 1 / sqrt(10^(rs/10) - 1)
+// The poles are the reciprocal of the Chebyshev type I poles for the same e and
+// the zeros lie on the imaginary axis at the reciprocal of the zeros of Tn
+//  z_m = j / cos(theta_m)   where theta_m = pi*(2*m - 1) / (2*n)
+// Odd order filters have one zero at infinity which becomes z = -1 after discretization.
+// The filter has unity gain at DC and the stopband starts at w = 1.
+
+// NewChebyshev2LP creates a low pass Chebyshev Type II filter from
+//  Fs: sampling frequency
+//  fs: stopband edge frequency, where the gain first reaches -rs dB.
+//  rs: minimum stopband attenuation in decibels.
+//  n: filter order
+func NewChebyshev2LP(Fs, fs, rs float64, n int) (*SOS, error) {
+	switch {
+	case n < 1:
+		return nil, ErrBadOrder
+	case rs <= 0:
+		return nil, ErrBadRipple
+	case fs <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	case fs >= Fs/2:
+		return nil, ErrAboveNyquist
+	}
+	ws := prewarp(Fs, fs)
+	return chebyshev2Prototype(n, rs).LPToLP(ws).bilinear(Fs).sos(), nil
+}
+
+// NewChebyshev2HP creates a high pass Chebyshev Type II filter from
+//  Fs: sampling frequency
+//  fs: stopband edge frequency, where the gain last reaches -rs dB.
+//  rs: minimum stopband attenuation in decibels.
+//  n: filter order
+func NewChebyshev2HP(Fs, fs, rs float64, n int) (*SOS, error) {
+	switch {
+	case n < 1:
+		return nil, ErrBadOrder
+	case rs <= 0:
+		return nil, ErrBadRipple
+	case fs <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	case fs >= Fs/2:
+		return nil, ErrAboveNyquist
+	}
+	ws := prewarp(Fs, fs)
+	return chebyshev2Prototype(n, rs).LPToHP(ws).bilinear(Fs).sos(), nil
+}
+
+// NewChebyshev2BP creates a band pass Chebyshev Type II filter from
+//  Fs: sampling frequency
+//  f1: lower stopband edge frequency, where the gain last reaches -rs dB.
+//  f2: upper stopband edge frequency, where the gain first reaches -rs dB.
+//  rs: minimum stopband attenuation in decibels.
+//  n: order of the low pass prototype. The resulting filter is of order 2*n.
+func NewChebyshev2BP(Fs, f1, f2, rs float64, n int) (*SOS, error) {
+	switch {
+	case n < 1:
+		return nil, ErrBadOrder
+	case rs <= 0:
+		return nil, ErrBadRipple
+	case f1 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	case f2 <= f1:
+		return nil, ErrNegBandwidth
+	case f2 >= Fs/2:
+		return nil, ErrAboveNyquist
+	}
+	w1, w2 := prewarp(Fs, f1), prewarp(Fs, f2)
+	return chebyshev2Prototype(n, rs).LPToBP(math.Sqrt(w1*w2), w2-w1).bilinear(Fs).sos(), nil
+}
+
+// NewChebyshev2BS creates a band stop Chebyshev Type II filter from
+//  Fs: sampling frequency
+//  f1: lower stopband edge frequency, where the gain first reaches -rs dB.
+//  f2: upper stopband edge frequency, where the gain last reaches -rs dB.
+//  rs: minimum stopband attenuation in decibels.
+//  n: order of the low pass prototype. The resulting filter is of order 2*n.
+func NewChebyshev2BS(Fs, f1, f2, rs float64, n int) (*SOS, error) {
+	switch {
+	case n < 1:
+		return nil, ErrBadOrder
+	case rs <= 0:
+		return nil, ErrBadRipple
+	case f1 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	case f2 <= f1:
+		return nil, ErrNegBandwidth
+	case f2 >= Fs/2:
+		return nil, ErrAboveNyquist
+	}
+	w1, w2 := prewarp(Fs, f1), prewarp(Fs, f2)
+	return chebyshev2Prototype(n, rs).LPToBS(math.Sqrt(w1*w2), w2-w1).bilinear(Fs).sos(), nil
+}
+
+// Chebyshev2Prototype returns the analog low pass Chebyshev Type II prototype
+// of order n and minimum stopband attenuation rs (dB) with stopband edge at 1 rad/s.
+// Invalid parameters return ErrBadOrder or ErrBadRipple.
+func Chebyshev2Prototype(n int, rs float64) (ZPK, error) {
+	switch {
+	case n < 1:
+		return ZPK{}, ErrBadOrder
+	case rs <= 0:
+		return ZPK{}, ErrBadRipple
+	}
+	return chebyshev2Prototype(n, rs), nil
+}
+
+// chebyshev2Prototype is Chebyshev2Prototype without parameter validation.
+func chebyshev2Prototype(n int, rs float64) ZPK {
+	e := 1 / math.Sqrt(math.Pow(10, rs/10)-1)
+	N := float64(n)
+	mu := math.Asinh(1/e) / N
+	z := make([]complex128, 0, n)
+	p := make([]complex128, n)
+	for m := 0; m < n; m++ {
+		theta := math.Pi * float64(2*m+1) / (2 * N)
+		if 2*m+1 != n { // Middle zero of odd orders is at infinity.
+			z = append(z, complex(0, 1/math.Cos(theta)))
+		}
+		p[m] = 1 / complex(-math.Sinh(mu)*math.Sin(theta), math.Cosh(mu)*math.Cos(theta))
+		if 2*m+1 == n {
+			p[m] = complex(-1/math.Sinh(mu), 0)
+		}
+	}
+	k := real(prodNeg(p) / prodNeg(z))
+	return ZPK{Z: z, P: p, K: k}
+}
@@ -0,0 +1,197 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestChebyshev1(t *testing.T) {
+	const (
+		fs  = 1e3
+		fc  = 100.
+		tol = 1e-9
+	)
+	dB := func(s *SOS, f float64) float64 {
+		return 20 * math.Log10(cmplx.Abs(s.getH()(cmplx.Rect(1, 2*math.Pi*f/fs))))
+	}
+	wc := math.Tan(math.Pi * fc / fs) // Pre-warped cutoff (2*fs factor cancels out).
+	for _, rp := range []float64{0.1, 0.5, 1, 3} {
+		e := math.Sqrt(math.Pow(10, rp/10) - 1)
+		for n := 1; n <= 8; n++ {
+			lp, err := NewChebyshev1LP(fs, fc, rp, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			hp, err := NewChebyshev1HP(fs, fc, rp, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Reference gain |H|^2 = 1/(1+e^2*Tn(w)^2) evaluated at pre-warped frequencies.
+			for f := 1.; f < fs/2; f += 7 {
+				w := math.Tan(math.Pi*f/fs) / wc
+				want := -10 * math.Log10(1+e*e*math.Pow(chebyshevPoly(n, w), 2))
+				if got := dB(lp, f); math.Abs(got-want) > 1e-6 {
+					t.Fatalf("rp=%g n=%d LP at %gHz: expected %gdB, got %gdB", rp, n, f, want, got)
+				}
+				want = -10 * math.Log10(1+e*e*math.Pow(chebyshevPoly(n, 1/w), 2))
+				if got := dB(hp, f); math.Abs(got-want) > 1e-6 {
+					t.Fatalf("rp=%g n=%d HP at %gHz: expected %gdB, got %gdB", rp, n, f, want, got)
+				}
+			}
+			// Equiripple passband: gain oscillates between 0 and -rp dB.
+			min, max := math.Inf(1), math.Inf(-1)
+			for f := 0.; f <= fc; f += fc / 2000 {
+				g := dB(lp, f)
+				min, max = math.Min(min, g), math.Max(max, g)
+			}
+			if max > tol || max < -1e-4 || math.Abs(min+rp) > tol {
+				t.Errorf("rp=%g n=%d: expected passband ripple in [-%g, 0]dB, got [%g, %g]", rp, n, rp, min, max)
+			}
+			if got := dB(lp, fc); math.Abs(got+rp) > tol {
+				t.Errorf("rp=%g n=%d: expected -%gdB at passband edge, got %gdB", rp, n, rp, got)
+			}
+			// -3dB frequency: w3 = cosh(acosh(1/e)/n) in the normalized analog domain.
+			f3 := fs / math.Pi * math.Atan(wc*math.Cosh(math.Acosh(1/e)/float64(n)))
+			if got := dB(lp, f3); rp < 3 && math.Abs(got-10*math.Log10(0.5)) > tol {
+				t.Errorf("rp=%g n=%d: expected -3dB at %gHz, got %gdB", rp, n, f3, got)
+			}
+		}
+	}
+	// Reference -3dB frequency for a 4th order, 1dB ripple filter with fc=100Hz at fs=1kHz.
+	lp, err := NewChebyshev1LP(fs, fc, 1, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := dB(lp, 104.93290996626105); math.Abs(got-10*math.Log10(0.5)) > 1e-6 {
+		t.Errorf("expected -3dB at reference frequency, got %gdB", got)
+	}
+	// Band pass and band stop gain from the prototype evaluated at transformed frequencies.
+	const (
+		f1 = 50.
+		f2 = 200.
+		rp = 1.
+	)
+	e := math.Sqrt(math.Pow(10, rp/10) - 1)
+	w1, w2 := math.Tan(math.Pi*f1/fs), math.Tan(math.Pi*f2/fs)
+	for n := 1; n <= 5; n++ {
+		bp, err := NewChebyshev1BP(fs, f1, f2, rp, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bs, err := NewChebyshev1BS(fs, f1, f2, rp, n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for f := 1.; f < fs/2; f += 7 {
+			w := math.Tan(math.Pi * f / fs)
+			wbp := (w*w - w1*w2) / (w * (w2 - w1))
+			want := -10 * math.Log10(1+e*e*math.Pow(chebyshevPoly(n, wbp), 2))
+			if got := dB(bp, f); math.Abs(got-want) > 1e-6 {
+				t.Fatalf("n=%d BP at %gHz: expected %gdB, got %gdB", n, f, want, got)
+			}
+			want = -10 * math.Log10(1+e*e*math.Pow(chebyshevPoly(n, 1/wbp), 2))
+			if got := dB(bs, f); math.Abs(got-want) > 1e-6 {
+				t.Fatalf("n=%d BS at %gHz: expected %gdB, got %gdB", n, f, want, got)
+			}
+		}
+	}
+	if _, err := NewChebyshev1BP(fs, f2, f1, rp, 4); err != ErrNegBandwidth {
+		t.Errorf("expected ErrNegBandwidth, got %v", err)
+	}
+	if _, err := NewChebyshev1LP(fs, fc, 0, 4); err != ErrBadRipple {
+		t.Errorf("expected ErrBadRipple, got %v", err)
+	}
+}
+
+// chebyshevPoly evaluates the Chebyshev polynomial of the first kind Tn(x).
+func chebyshevPoly(n int, x float64) float64 {
+	N := float64(n)
+	switch {
+	case math.Abs(x) <= 1:
+		return math.Cos(N * math.Acos(x))
+	case x > 1:
+		return math.Cosh(N * math.Acosh(x))
+	}
+	if n%2 == 1 {
+		return -math.Cosh(N * math.Acosh(-x))
+	}
+	return math.Cosh(N * math.Acosh(-x))
+}
+
+func TestChebyshev2(t *testing.T) {
+	const (
+		fs  = 1e3
+		fst = 100.
+		f1  = 100.
+		f2  = 250.
+		tol = 1e-6
+	)
+	dB := func(s *SOS, f float64) float64 {
+		return 20 * math.Log10(cmplx.Abs(s.getH()(cmplx.Rect(1, 2*math.Pi*f/fs))))
+	}
+	ws := math.Tan(math.Pi * fst / fs) // Pre-warped stopband edge (2*fs factor cancels out).
+	for _, rs := range []float64{20, 40, 60} {
+		e := 1 / math.Sqrt(math.Pow(10, rs/10)-1)
+		for n := 1; n <= 8; n++ {
+			lp, err := NewChebyshev2LP(fs, fst, rs, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			hp, err := NewChebyshev2HP(fs, fst, rs, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			bp, err := NewChebyshev2BP(fs, f1, f2, rs, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			bs, err := NewChebyshev2BS(fs, f1, f2, rs, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Reference gain |H|^2 = 1/(1+1/(e^2*Tn(1/w)^2)) evaluated at pre-warped frequencies.
+			for f := 3.; f < fs/2; f += 7 {
+				w := math.Tan(math.Pi*f/fs) / ws
+				want := -10 * math.Log10(1+1/(e*e*math.Pow(chebyshevPoly(n, 1/w), 2)))
+				if got := dB(lp, f); math.Abs(got-want) > tol && want > -200 {
+					t.Fatalf("rs=%g n=%d LP at %gHz: expected %gdB, got %gdB", rs, n, f, want, got)
+				}
+				want = -10 * math.Log10(1+1/(e*e*math.Pow(chebyshevPoly(n, w), 2)))
+				if got := dB(hp, f); math.Abs(got-want) > tol && want > -200 {
+					t.Fatalf("rs=%g n=%d HP at %gHz: expected %gdB, got %gdB", rs, n, f, want, got)
+				}
+			}
+			// Flat passband and equiripple stopband floor at -rs dB.
+			if got := dB(lp, 0); math.Abs(got) > 1e-9 {
+				t.Errorf("rs=%g n=%d: expected 0dB at DC, got %gdB", rs, n, got)
+			}
+			for _, test := range []struct {
+				name           string
+				s              *SOS
+				stopLo, stopHi float64
+			}{
+				{"LP", lp, fst, fs / 2},
+				{"HP", hp, 0, fst},
+				{"BP low", bp, 0, f1},
+				{"BP high", bp, f2, fs / 2},
+				{"BS", bs, f1, f2},
+			} {
+				max := math.Inf(-1)
+				for f := test.stopLo; f <= test.stopHi; f += (test.stopHi - test.stopLo) / 1000 {
+					max = math.Max(max, dB(test.s, f))
+				}
+				if math.Abs(max+rs) > 1e-3 {
+					t.Errorf("rs=%g n=%d %s: expected stopband floor of -%gdB, got %gdB", rs, n, test.name, rs, max)
+				}
+			}
+			f0 := fs / math.Pi * math.Atan(math.Sqrt(math.Tan(math.Pi*f1/fs)*math.Tan(math.Pi*f2/fs)))
+			if got := dB(bp, f0); math.Abs(got) > 1e-9 {
+				t.Errorf("rs=%g n=%d: expected 0dB at band pass center, got %gdB", rs, n, got)
+			}
+			if got := dB(bs, 0); math.Abs(got) > 1e-9 {
+				t.Errorf("rs=%g n=%d: expected 0dB at band stop DC, got %gdB", rs, n, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Constantinides transformations map a digital low pass filter with cutoff
+// fc onto another frequency selective filter by substituting z^{-1} with an
+// all-pass function g(z^{-1}). With normalized frequencies th = 2*pi*fc/Fs,
+// w = 2*pi*fn/Fs, w1 = 2*pi*f1/Fs and w2 = 2*pi*f2/Fs:
+//  LP to LP:  z^{-1} -> (z^{-1} - a) / (1 - a*z^{-1})
+//   a = sin((th-w)/2) / sin((th+w)/2)
+//  LP to HP:  z^{-1} -> -(z^{-1} + a) / (1 + a*z^{-1})
+//   a = -cos((th+w)/2) / cos((th-w)/2)
+//  LP to BP:  z^{-1} -> -(z^{-2} - 2*a*k/(k+1)*z^{-1} + (k-1)/(k+1)) / ((k-1)/(k+1)*z^{-2} - 2*a*k/(k+1)*z^{-1} + 1)
+//   a = cos((w2+w1)/2) / cos((w2-w1)/2)    k = cot((w2-w1)/2) * tan(th/2)
+//  LP to BS:  z^{-1} -> (z^{-2} - 2*a/(1+k)*z^{-1} + (1-k)/(1+k)) / ((1-k)/(1+k)*z^{-2} - 2*a/(1+k)*z^{-1} + 1)
+//   a = cos((w2+w1)/2) / cos((w2-w1)/2)    k = tan((w2-w1)/2) * tan(th/2)
+// The band pass and band stop transformations double the order of the filter.
+// See A. G. Constantinides, "Spectral transformations for digital filters" (1970).
+
+// TransformLP transforms the digital transfer function of a low pass filter with
+// cutoff frequency fc into a low pass filter with cutoff frequency fn, sampled at Fs.
+// The transfer function of a biquad or cascade is obtained with its ZPK method.
+// Unlike the analog ZPK.LPToLP family, frequencies are in Hz and lp is digital.
+// lp must be a proper transfer function with complex roots in conjugate pairs
+// or ErrImproperZPK or ErrNotConjugate is returned.
+func TransformLP(lp ZPK, Fs, fc, fn float64) (*SOS, error) {
+	N, D, err := lpToLP(Fs, fc, fn)
+	if err != nil {
+		return nil, err
+	}
+	return lp.transform(N, D)
+}
+
+// TransformHP transforms the digital transfer function of a low pass filter with
+// cutoff frequency fc into a high pass filter with cutoff frequency fn, sampled at Fs.
+func TransformHP(lp ZPK, Fs, fc, fn float64) (*SOS, error) {
+	N, D, err := lpToHP(Fs, fc, fn)
+	if err != nil {
+		return nil, err
+	}
+	return lp.transform(N, D)
+}
+
+// TransformBP transforms the digital transfer function of a low pass filter with
+// cutoff frequency fc into a band pass filter with band edges f1 and f2, sampled at Fs.
+func TransformBP(lp ZPK, Fs, fc, f1, f2 float64) (*SOS, error) {
+	N, D, err := lpToBP(Fs, fc, f1, f2)
+	if err != nil {
+		return nil, err
+	}
+	return lp.transform(N, D)
+}
+
+// TransformBS transforms the digital transfer function of a low pass filter with
+// cutoff frequency fc into a band stop filter with band edges f1 and f2, sampled at Fs.
+func TransformBS(lp ZPK, Fs, fc, f1, f2 float64) (*SOS, error) {
+	N, D, err := lpToBS(Fs, fc, f1, f2)
+	if err != nil {
+		return nil, err
+	}
+	return lp.transform(N, D)
+}
+
+// TransformLP transforms the low pass biquad with cutoff frequency fc into
+// a low pass filter with cutoff frequency fn, sampled at Fs. See the function TransformLP.
+func (lp *LowPass) TransformLP(Fs, fc, fn float64) (*SOS, error) {
+	return TransformLP(lp.ZPK(), Fs, fc, fn)
+}
+
+// TransformHP transforms the low pass biquad with cutoff frequency fc into
+// a high pass filter with cutoff frequency fn, sampled at Fs. See the function TransformHP.
+func (lp *LowPass) TransformHP(Fs, fc, fn float64) (*SOS, error) {
+	return TransformHP(lp.ZPK(), Fs, fc, fn)
+}
+
+// TransformBP transforms the low pass biquad with cutoff frequency fc into
+// a band pass filter with band edges f1 and f2, sampled at Fs. See the function TransformBP.
+func (lp *LowPass) TransformBP(Fs, fc, f1, f2 float64) (*SOS, error) {
+	return TransformBP(lp.ZPK(), Fs, fc, f1, f2)
+}
+
+// TransformBS transforms the low pass biquad with cutoff frequency fc into
+// a band stop filter with band edges f1 and f2, sampled at Fs. See the function TransformBS.
+func (lp *LowPass) TransformBS(Fs, fc, f1, f2 float64) (*SOS, error) {
+	return TransformBS(lp.ZPK(), Fs, fc, f1, f2)
+}
+
+// TransformLP transforms the low pass cascade with cutoff frequency fc into
+// a low pass cascade with cutoff frequency fn, sampled at Fs. See the function TransformLP.
+func (s *SOS) TransformLP(Fs, fc, fn float64) (*SOS, error) {
+	return TransformLP(s.ZPK(), Fs, fc, fn)
+}
+
+// TransformHP transforms the low pass cascade with cutoff frequency fc into
+// a high pass cascade with cutoff frequency fn, sampled at Fs. See the function TransformHP.
+func (s *SOS) TransformHP(Fs, fc, fn float64) (*SOS, error) {
+	return TransformHP(s.ZPK(), Fs, fc, fn)
+}
+
+// TransformBP transforms the low pass cascade with cutoff frequency fc into
+// a band pass cascade with band edges f1 and f2, sampled at Fs. See the function TransformBP.
+func (s *SOS) TransformBP(Fs, fc, f1, f2 float64) (*SOS, error) {
+	return TransformBP(s.ZPK(), Fs, fc, f1, f2)
+}
+
+// TransformBS transforms the low pass cascade with cutoff frequency fc into
+// a band stop cascade with band edges f1 and f2, sampled at Fs. See the function TransformBS.
+func (s *SOS) TransformBS(Fs, fc, f1, f2 float64) (*SOS, error) {
+	return TransformBS(s.ZPK(), Fs, fc, f1, f2)
+}
+
+// The following functions return the all-pass substitution N/D with
+// coefficients in ascending powers of z^{-1}.
+
+func lpToLP(Fs, fc, fn float64) (N, D []float64, err error) {
+	if err := checkFreqs(Fs, fc, fn); err != nil {
+		return nil, nil, err
+	}
+	th, w := 2*math.Pi*fc/Fs, 2*math.Pi*fn/Fs
+	a := math.Sin((th-w)/2) / math.Sin((th+w)/2)
+	return []float64{-a, 1}, []float64{1, -a}, nil
+}
+
+func lpToHP(Fs, fc, fn float64) (N, D []float64, err error) {
+	if err := checkFreqs(Fs, fc, fn); err != nil {
+		return nil, nil, err
+	}
+	th, w := 2*math.Pi*fc/Fs, 2*math.Pi*fn/Fs
+	a := -math.Cos((th+w)/2) / math.Cos((th-w)/2)
+	return []float64{-a, -1}, []float64{1, a}, nil
+}
+
+func lpToBP(Fs, fc, f1, f2 float64) (N, D []float64, err error) {
+	if err := checkBand(Fs, fc, f1, f2); err != nil {
+		return nil, nil, err
+	}
+	th, w1, w2 := 2*math.Pi*fc/Fs, 2*math.Pi*f1/Fs, 2*math.Pi*f2/Fs
+	a := math.Cos((w2+w1)/2) / math.Cos((w2-w1)/2)
+	k := math.Tan(th/2) / math.Tan((w2-w1)/2)
+	c1, c2 := 2*a*k/(k+1), (k-1)/(k+1)
+	return []float64{-c2, c1, -1}, []float64{1, -c1, c2}, nil
+}
+
+func lpToBS(Fs, fc, f1, f2 float64) (N, D []float64, err error) {
+	if err := checkBand(Fs, fc, f1, f2); err != nil {
+		return nil, nil, err
+	}
+	th, w1, w2 := 2*math.Pi*fc/Fs, 2*math.Pi*f1/Fs, 2*math.Pi*f2/Fs
+	a := math.Cos((w2+w1)/2) / math.Cos((w2-w1)/2)
+	k := math.Tan((w2-w1)/2) * math.Tan(th/2)
+	c1, c2 := 2*a/(1+k), (1-k)/(1+k)
+	return []float64{c2, -c1, 1}, []float64{1, -c1, c2}, nil
+}
+
+func checkFreqs(Fs float64, f ...float64) error {
+	if Fs <= 0 {
+		return ErrBadFreq
+	}
+	for _, f := range f {
+		switch {
+		case f <= 0:
+			return ErrBadFreq
+		case f >= Fs/2:
+			return ErrAboveNyquist
+		}
+	}
+	return nil
+}
+
+func checkBand(Fs, fc, f1, f2 float64) error {
+	if f2 <= f1 {
+		return ErrNegBandwidth
+	}
+	return checkFreqs(Fs, fc, f1, f2)
+}
+
+// transform validates the digital transfer function h and substitutes z^{-1} with N/D.
+func (h ZPK) transform(N, D []float64) (*SOS, error) {
+	if err := h.checkRealProper(); err != nil {
+		return nil, err
+	}
+	return h.substitute(N, D).sos(), nil
+}
+
+// substitute replaces z^{-1} with N/D in a digital transfer function.
+// Every root r maps to the roots z of
+//  r*N(1/z) - D(1/z) = 0
+// and every zero at infinity (delay) maps to the roots of N(1/z).
+// Matching zeros and poles at the origin are cancelled beforehand so they
+// do not map to spurious roots. The gain is found by evaluating both
+// transfer functions at a test point.
+func (h ZPK) substitute(N, D []float64) ZPK {
+	old := h.cancelOrigin()
+	var t ZPK
+	for _, r := range old.Z {
+		t.Z = append(t.Z, mapRoot(r, N, D)...)
+	}
+	for i := len(old.Z); i < len(old.P); i++ {
+		t.Z = append(t.Z, mapRoot(0, N, nil)...)
+	}
+	for _, r := range old.P {
+		t.P = append(t.P, mapRoot(r, N, D)...)
+	}
+	forceConjugates(t.Z)
+	forceConjugates(t.P)
+	const z = 1.5 + 0.9i
+	w := 1 / z
+	zold := polyEvalAsc(D, w) / polyEvalAsc(N, w)
+	t.K = 1 // Evaluate with unity gain to find the actual gain.
+	t.K = real(old.eval(zold) / t.eval(z))
+	return t
+}
+
+// cancelOrigin returns a copy of h without matching pairs of zeros and poles at the origin.
+func (h ZPK) cancelOrigin() ZPK {
+	z := append([]complex128{}, h.Z...)
+	p := append([]complex128{}, h.P...)
+	for {
+		iz, ip := indexOf(z, 0), indexOf(p, 0)
+		if iz < 0 || ip < 0 {
+			break
+		}
+		z, p = removeAt(z, iz), removeAt(p, ip)
+	}
+	return ZPK{Z: z, P: p, K: h.K}
+}
+
+func indexOf(roots []complex128, r complex128) int {
+	for i := range roots {
+		if roots[i] == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// mapRoot returns the finite roots in z of r*N(1/z) - D(1/z) = 0 where N and D
+// have coefficients in ascending powers of z^{-1}. Multiplying by z^n yields a polynomial
+// in z with the same coefficients in descending powers.
+func mapRoot(r complex128, N, D []float64) []complex128 {
+	c := make([]complex128, len(N))
+	for i := range N {
+		c[i] = r * complex(N[i], 0)
+		if D != nil {
+			c[i] -= complex(D[i], 0)
+		}
+	}
+	for len(c) > 0 && c[0] == 0 {
+		c = c[1:] // Root at infinity.
+	}
+	switch len(c) {
+	case 2:
+		return []complex128{-c[1] / c[0]}
+	case 3:
+		disc := cmplx.Sqrt(c[1]*c[1] - 4*c[0]*c[2])
+		// Avoid cancellation by choosing the sign of the square root.
+		if real(cmplx.Conj(c[1])*disc) < 0 {
+			disc = -disc
+		}
+		q := -(c[1] + disc) / 2
+		if q == 0 {
+			return []complex128{0, 0}
+		}
+		return []complex128{q / c[0], c[2] / q}
+	}
+	return nil
+}
+
+// polyEvalAsc evaluates the polynomial with coefficients c in ascending powers at x.
+func polyEvalAsc(c []float64, x complex128) complex128 {
+	var p complex128
+	for i := len(c) - 1; i >= 0; i-- {
+		p = p*x + complex(c[i], 0)
+	}
+	return p
+}
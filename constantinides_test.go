@@ -0,0 +1,82 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestConstantinides(t *testing.T) {
+	const (
+		fs = 1e3
+		fc = 100.
+		fn = 180.
+		f1 = 50.
+		f2 = 200.
+	)
+	lp2, err := NewButterworthLP(fs, fc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lp3, err := NewButterworthLPN(fs, fc, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cookbook, err := NewLowPassQ(fs, fc, math.Sqrt2/2) // Second order Butterworth.
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Transformations of a bilinear Butterworth design yield the bilinear design
+	// of the target filter, so the responses can be compared directly.
+	type design struct {
+		name    string
+		got     func() (*SOS, error)
+		want    func() (*SOS, error)
+		wantLen int
+	}
+	for _, test := range []design{
+		{"biquad LP", func() (*SOS, error) { return TransformLP(lp2.ZPK(), fs, fc, fn) }, func() (*SOS, error) { return NewButterworthLPN(fs, fn, 2) }, 1},
+		{"biquad HP", func() (*SOS, error) { return TransformHP(lp2.ZPK(), fs, fc, fn) }, func() (*SOS, error) { return NewButterworthHPN(fs, fn, 2) }, 1},
+		{"biquad BP", func() (*SOS, error) { return TransformBP(lp2.ZPK(), fs, fc, f1, f2) }, func() (*SOS, error) { return NewButterworthBPN(fs, f1, f2, 2) }, 2},
+		{"biquad BS", func() (*SOS, error) { return TransformBS(lp2.ZPK(), fs, fc, f1, f2) }, func() (*SOS, error) { return NewButterworthBSN(fs, f1, f2, 2) }, 2},
+		{"low pass LP", func() (*SOS, error) { return cookbook.TransformLP(fs, fc, fn) }, func() (*SOS, error) { return NewButterworthLPN(fs, fn, 2) }, 1},
+		{"low pass BS", func() (*SOS, error) { return cookbook.TransformBS(fs, fc, f1, f2) }, func() (*SOS, error) { return NewButterworthBSN(fs, f1, f2, 2) }, 2},
+		{"SOS LP", func() (*SOS, error) { return lp3.TransformLP(fs, fc, fn) }, func() (*SOS, error) { return NewButterworthLPN(fs, fn, 3) }, 2},
+		{"SOS HP", func() (*SOS, error) { return lp3.TransformHP(fs, fc, fn) }, func() (*SOS, error) { return NewButterworthHPN(fs, fn, 3) }, 2},
+		{"SOS BP", func() (*SOS, error) { return lp3.TransformBP(fs, fc, f1, f2) }, func() (*SOS, error) { return NewButterworthBPN(fs, f1, f2, 3) }, 3},
+		{"SOS BS", func() (*SOS, error) { return lp3.TransformBS(fs, fc, f1, f2) }, func() (*SOS, error) { return NewButterworthBSN(fs, f1, f2, 3) }, 3},
+	} {
+		got, err := test.got()
+		if err != nil {
+			t.Fatal(test.name, err)
+		}
+		want, err := test.want()
+		if err != nil {
+			t.Fatal(test.name, err)
+		}
+		if got.Len() != test.wantLen {
+			t.Errorf("%s: expected %d sections, got %d", test.name, test.wantLen, got.Len())
+		}
+		for f := 0.; f <= fs/2; f += 12.5 {
+			z := cmplx.Rect(1, 2*math.Pi*f/fs)
+			if g, w := got.getH()(z), want.getH()(z); cmplx.Abs(g-w) > 1e-9 {
+				t.Errorf("%s at %gHz: expected %v, got %v", test.name, f, w, g)
+				break
+			}
+		}
+	}
+	if _, err := TransformLP(lp2.ZPK(), fs, fc, fs/2); err != ErrAboveNyquist {
+		t.Errorf("expected ErrAboveNyquist, got %v", err)
+	}
+	if _, err := lp3.TransformBP(fs, fc, f2, f1); err != ErrNegBandwidth {
+		t.Errorf("expected ErrNegBandwidth, got %v", err)
+	}
+	improper := ZPK{Z: []complex128{0.5, 0.3, -0.2}, P: []complex128{0.1}, K: 1}
+	if _, err := TransformLP(improper, fs, fc, fn); err != ErrImproperZPK {
+		t.Errorf("expected ErrImproperZPK, got %v", err)
+	}
+	unpaired := ZPK{P: []complex128{0.5 + 0.2i}, K: 1}
+	if _, err := TransformHP(unpaired, fs, fc, fn); err != ErrNotConjugate {
+		t.Errorf("expected ErrNotConjugate, got %v", err)
+	}
+}
@@ -0,0 +1,349 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Discretization is a method for mapping an analog transfer function H(s)
+// to a digital transfer function H(z) with sampling period T = 1/Fs.
+type Discretization int
+
+const (
+	// Tustin is the bilinear transformation without pre-warping
+	//  s = 2/T * (z-1)/(z+1)
+	// It maps the whole jw axis onto the unit circle, preserving stability and
+	// the DC gain at the cost of compressing high frequencies (frequency warping).
+	Tustin Discretization = iota
+	// ZOH (zero-order hold) yields a digital system whose step response
+	// matches the analog step response at the sampling instants.
+	//  H(z) = (1 - z^{-1}) * Z{H(s)/s}
+	ZOH
+	// ForwardEuler approximates the derivative with a forward difference
+	//  s = (z-1)/T
+	// A stable analog system may yield an unstable digital system for large T.
+	ForwardEuler
+	// BackwardEuler approximates the derivative with a backward difference
+	//  s = (z-1)/(T*z)
+	BackwardEuler
+	// MatchedZ maps every zero and pole with z = e^{s*T}. Zeros at infinity are
+	// mapped to the Nyquist frequency (z = -1). The gain is matched at DC, or at Fs/4
+	// if the analog transfer function has a zero or pole at s = 0.
+	MatchedZ
+	// ImpulseInvariant yields a digital system whose impulse response is
+	// the analog impulse response sampled at t = n*T, scaled by T.
+	//  H(z) = T * SUM r_i / (1 - e^{p_i*T} * z^{-1})
+	// where r_i is the residue of H(s) at pole p_i. Aliasing of the analog response
+	// makes it only suitable for band limited responses, such as low pass filters.
+	ImpulseInvariant
+)
+
+// Discretize converts an analog transfer function in zero-pole-gain
+// form (zeros and poles in rad/s) to a digital cascade of second order sections.
+//  Fs: sampling frequency
+//  method: discretization method.
+// ZOH and ImpulseInvariant require distinct poles. ImpulseInvariant also
+// requires a strictly proper transfer function (more poles than zeros).
+func Discretize(analog ZPK, Fs float64, method Discretization) (*SOS, error) {
+	switch {
+	case Fs <= 0:
+		return nil, ErrBadFreq
+	case len(analog.Z) > len(analog.P):
+		return nil, ErrImproperZPK
+	case !hasConjugates(analog.Z) || !hasConjugates(analog.P):
+		return nil, ErrNotConjugate
+	}
+	T := 1 / Fs
+	switch method {
+	case Tustin:
+		return analog.bilinear(Fs).sos(), nil
+	case ForwardEuler:
+		return analog.forwardEuler(T).sos(), nil
+	case BackwardEuler:
+		return analog.backwardEuler(T).sos(), nil
+	case MatchedZ:
+		return analog.matchedZ(T).sos(), nil
+	case ZOH, ImpulseInvariant:
+		if hasRepeated(analog.P) {
+			return nil, ErrRepeatedPoles
+		}
+		if method == ZOH {
+			return analog.zoh(T).sos(), nil
+		}
+		if len(analog.Z) == len(analog.P) {
+			return nil, ErrNotStrict
+		}
+		return analog.impulseInvariant(T).sos(), nil
+	}
+	return nil, ErrBadMethod
+}
+
+// Bilinear discretizes an analog transfer function H(s) given in zero-pole-gain
+// form (zeros and poles in rad/s) with the bilinear (Tustin) transformation and
+// returns the resulting cascade of second order sections.
+//  Fs: sampling frequency
+//  fw: pre-warping frequency in Hz. The digital and analog responses match exactly
+//      at this frequency. If fw is zero no pre-warping is applied:
+//       s = 2*Fs * (z-1)/(z+1)
+//      otherwise
+//       s = 2*pi*fw/tan(pi*fw/Fs) * (z-1)/(z+1)
+func Bilinear(analog ZPK, Fs, fw float64) (*SOS, error) {
+	switch {
+	case Fs <= 0 || fw < 0:
+		return nil, ErrBadFreq
+	case fw >= Fs/2:
+		return nil, ErrAboveNyquist
+	case len(analog.Z) > len(analog.P):
+		return nil, ErrImproperZPK
+	case !hasConjugates(analog.Z) || !hasConjugates(analog.P):
+		return nil, ErrNotConjugate
+	}
+	return analog.bilinear(bilinearFs(Fs, fw)).sos(), nil
+}
+
+// BilinearPoly discretizes an analog transfer function given as polynomials in s
+//  H(s) = (b[0]*s^m + b[1]*s^(m-1) + ... + b[m]) / (a[0]*s^n + a[1]*s^(n-1) + ... + a[n])
+// with the bilinear (Tustin) transformation. See Bilinear for the meaning of Fs and fw.
+func BilinearPoly(b, a []float64, Fs, fw float64) (*SOS, error) {
+	analog, err := ZPKFromPoly(b, a)
+	if err != nil {
+		return nil, err
+	}
+	return Bilinear(analog, Fs, fw)
+}
+
+// ZPKFromPoly converts a transfer function given as numerator and denominator
+// polynomial coefficients in descending powers into zero-pole-gain form.
+//  H(x) = (b[0]*x^m + ... + b[m]) / (a[0]*x^n + ... + a[n])
+// Leading zero coefficients are ignored.
+func ZPKFromPoly(b, a []float64) (ZPK, error) {
+	b, a = trimLeadingZeros(b), trimLeadingZeros(a)
+	switch {
+	case len(a) == 0:
+		return ZPK{}, ErrBadCoefficients
+	case len(b) == 0:
+		return ZPK{K: 0}, nil
+	}
+	h := ZPK{
+		Z: polyRoots(realToComplex(b)),
+		P: polyRoots(realToComplex(a)),
+		K: b[0] / a[0],
+	}
+	forceConjugates(h.Z)
+	forceConjugates(h.P)
+	return h, nil
+}
+
+// bilinearFs returns the sampling frequency to use in the bilinear transformation
+// such that analog frequency fw (Hz) maps to digital frequency fw.
+func bilinearFs(Fs, fw float64) float64 {
+	if fw == 0 {
+		return Fs
+	}
+	return math.Pi * fw / math.Tan(math.Pi*fw/Fs)
+}
+
+func trimLeadingZeros(c []float64) []float64 {
+	for len(c) > 0 && c[0] == 0 {
+		c = c[1:]
+	}
+	return c
+}
+
+func realToComplex(c []float64) []complex128 {
+	cc := make([]complex128, len(c))
+	for i := range c {
+		cc[i] = complex(c[i], 0)
+	}
+	return cc
+}
+
+// forwardEuler maps roots with z = 1 + s*T. Zeros at infinity remain at infinity.
+func (h ZPK) forwardEuler(T float64) ZPK {
+	z := make([]complex128, len(h.Z))
+	p := make([]complex128, len(h.P))
+	for i := range h.Z {
+		z[i] = 1 + h.Z[i]*complex(T, 0)
+	}
+	for i := range h.P {
+		p[i] = 1 + h.P[i]*complex(T, 0)
+	}
+	// (z-1)/T - r = (z - (1 + r*T))/T
+	k := h.K * math.Pow(T, float64(len(h.P)-len(h.Z)))
+	return ZPK{Z: z, P: p, K: k}
+}
+
+// backwardEuler maps roots with z = 1/(1 - s*T). Zeros at infinity map to z = 0.
+func (h ZPK) backwardEuler(T float64) ZPK {
+	z := make([]complex128, 0, len(h.P))
+	p := make([]complex128, len(h.P))
+	// (z-1)/(T*z) - r = (1 - r*T) * (z - 1/(1 - r*T)) / (T*z)
+	g := complex(h.K, 0)
+	for i := range h.Z {
+		d := 1 - h.Z[i]*complex(T, 0)
+		z = append(z, 1/d)
+		g *= d
+	}
+	for i := range h.P {
+		d := 1 - h.P[i]*complex(T, 0)
+		p[i] = 1 / d
+		g /= d
+	}
+	for len(z) < len(p) {
+		z = append(z, 0)
+	}
+	k := real(g) * math.Pow(T, float64(len(h.P)-len(h.Z)))
+	return ZPK{Z: z, P: p, K: k}
+}
+
+// matchedZ maps roots with z = e^{s*T} and zeros at infinity to z = -1.
+func (h ZPK) matchedZ(T float64) ZPK {
+	tc := complex(T, 0)
+	z := make([]complex128, 0, len(h.P))
+	p := make([]complex128, len(h.P))
+	for i := range h.Z {
+		z = append(z, cmplx.Exp(h.Z[i]*tc))
+	}
+	for len(z) < len(p) {
+		z = append(z, -1)
+	}
+	for i := range h.P {
+		p[i] = cmplx.Exp(h.P[i] * tc)
+	}
+	d := ZPK{Z: z, P: p, K: 1}
+	// Match gain at DC unless there is a zero or pole at s = 0.
+	s0, z0 := complex(0, 0), complex(1, 0)
+	if hasOrigin(h.Z) || hasOrigin(h.P) {
+		s0, z0 = complex(0, math.Pi/(2*T)), 1i
+	}
+	ratio := h.eval(s0) / d.eval(z0)
+	if s0 == 0 {
+		d.K = real(ratio)
+	} else {
+		d.K = math.Copysign(cmplx.Abs(ratio), h.K)
+	}
+	return d
+}
+
+// zoh returns the zero-order hold discretization of h, which is expanded in partial fractions
+//  H(s) = D + SUM r_i / (s - p_i)
+//  H(z) = D + SUM c_i / (z - a_i)    a_i = e^{p_i*T}
+// with c_i = r_i*(a_i - 1)/p_i, or c_i = r_i*T for a pole at the origin.
+func (h ZPK) zoh(T float64) ZPK {
+	r := h.residues()
+	a := make([]complex128, len(h.P))
+	c := make([]complex128, len(h.P))
+	for i, p := range h.P {
+		a[i] = cmplx.Exp(p * complex(T, 0))
+		if p == 0 {
+			c[i] = r[i] * complex(T, 0)
+		} else {
+			c[i] = r[i] * (a[i] - 1) / p
+		}
+	}
+	var D float64
+	if len(h.Z) == len(h.P) {
+		D = h.K
+	}
+	return partialFractions(a, c, complex(D, 0), false)
+}
+
+// impulseInvariant returns the impulse invariant discretization of strictly proper h
+//  H(z) = SUM T*r_i * z / (z - a_i)    a_i = e^{p_i*T}
+func (h ZPK) impulseInvariant(T float64) ZPK {
+	r := h.residues()
+	a := make([]complex128, len(h.P))
+	for i, p := range h.P {
+		a[i] = cmplx.Exp(p * complex(T, 0))
+		r[i] *= complex(T, 0)
+	}
+	return partialFractions(a, r, 0, true)
+}
+
+// residues returns the residues of h at its poles, which must be distinct.
+//  r_i = K * PROD(p_i - z_j) / PROD_{j!=i}(p_i - p_j)
+func (h ZPK) residues() []complex128 {
+	r := make([]complex128, len(h.P))
+	for i, p := range h.P {
+		r[i] = complex(h.K, 0)
+		for _, z := range h.Z {
+			r[i] *= p - z
+		}
+		for j, pj := range h.P {
+			if j != i {
+				r[i] /= p - pj
+			}
+		}
+	}
+	return r
+}
+
+// partialFractions returns the zero-pole-gain form of
+//  H(z) = d + SUM c_i / (z - a_i)
+// or, if shift is true
+//  H(z) = d + SUM c_i * z / (z - a_i)
+// The numerator is expanded as a polynomial and its roots found numerically.
+func partialFractions(a, c []complex128, d complex128, shift bool) ZPK {
+	num := polyFromRoots(a)
+	for i := range num {
+		num[i] *= d
+	}
+	for i := range a {
+		term := polyFromRoots(removeAt(append([]complex128{}, a...), i))
+		if shift {
+			term = append(term, 0)
+		}
+		off := len(num) - len(term)
+		for j := range term {
+			num[off+j] += c[i] * term[j]
+		}
+	}
+	// The numerator has real coefficients. Cancellation may
+	// leave negligible leading coefficients which are discarded.
+	for i := range num {
+		num[i] = complex(real(num[i]), 0)
+	}
+	num = polyTrim(num)
+	if len(num) == 0 {
+		return ZPK{P: a}
+	}
+	z := polyRoots(num)
+	forceConjugates(z)
+	p := append([]complex128{}, a...)
+	forceConjugates(p)
+	return ZPK{Z: z, P: p, K: real(num[0])}
+}
+
+// eval evaluates the transfer function at x (s or z depending on domain).
+func (h ZPK) eval(x complex128) complex128 {
+	v := complex(h.K, 0)
+	for _, z := range h.Z {
+		v *= x - z
+	}
+	for _, p := range h.P {
+		v /= x - p
+	}
+	return v
+}
+
+// hasRepeated reports whether roots contains repeated values.
+func hasRepeated(roots []complex128) bool {
+	for i := range roots {
+		for j := i + 1; j < len(roots); j++ {
+			if cmplx.Abs(roots[i]-roots[j]) <= conjTol*math.Max(1, cmplx.Abs(roots[i])) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasOrigin reports whether roots contains a root at the origin.
+func hasOrigin(roots []complex128) bool {
+	for _, r := range roots {
+		if cmplx.Abs(r) <= conjTol {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestBilinear(t *testing.T) {
+	const (
+		fs = 1e3
+		fc = 100.
+	)
+	// Second order analog Butterworth low pass with pre-warped cutoff.
+	wc := prewarp(fs, fc)
+	b := []float64{wc * wc}
+	a := []float64{1, math.Sqrt2 * wc, wc * wc}
+	got, err := BilinearPoly(b, a, fs, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := NewButterworthLPN(fs, fc, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []float64{0, 10, fc, 300, 499} {
+		z := cmplx.Rect(1, 2*math.Pi*f/fs)
+		if g, w := got.getH()(z), want.getH()(z); cmplx.Abs(g-w) > 1e-12 {
+			t.Errorf("at %gHz: expected %v, got %v", f, w, g)
+		}
+	}
+
+	// Third order analog system with a zero: H(s) = 5e4*(s+20) / ((s+1)*(s^2+600*s+130000)),
+	// a real pole at s=-1 and a complex pair at s=-300±200j.
+	analog := ZPK{Z: []complex128{-20}, P: []complex128{-1, -300 + 200i, -300 - 200i}, K: 5e4}
+	Ha := func(f float64) complex128 {
+		s := complex(0, 2*math.Pi*f)
+		h := complex(analog.K, 0)
+		for _, z := range analog.Z {
+			h *= s - z
+		}
+		for _, p := range analog.P {
+			h /= s - p
+		}
+		return h
+	}
+	for _, fw := range []float64{50, 200, 400} {
+		d, err := Bilinear(analog, fs, fw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Pre-warping makes the responses match exactly at fw.
+		if g, w := d.getH()(cmplx.Rect(1, 2*math.Pi*fw/fs)), Ha(fw); cmplx.Abs(g-w) > 1e-9*cmplx.Abs(w) {
+			t.Errorf("pre-warped at %gHz: expected %v, got %v", fw, w, g)
+		}
+	}
+	d, err := Bilinear(analog, fs, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := d.getH()(cmplx.Rect(1, 2*math.Pi*400/fs)), Ha(400); cmplx.Abs(g-w) < 1e-3*cmplx.Abs(w) {
+		t.Errorf("expected frequency warping without pre-warp at 400Hz, got %v for analog %v", g, w)
+	}
+	if _, err := Bilinear(analog, fs, fs/2); err != ErrAboveNyquist {
+		t.Errorf("expected ErrAboveNyquist, got %v", err)
+	}
+	if _, err := BilinearPoly([]float64{1, 2, 3}, []float64{1, 1}, fs, 0); err != ErrImproperZPK {
+		t.Errorf("expected ErrImproperZPK, got %v", err)
+	}
+}
+
+func TestDiscretize(t *testing.T) {
+	const (
+		fs   = 1e3
+		f0   = 50.
+		zeta = 0.3
+	)
+	// Underdamped second order analog low pass with unity DC gain.
+	w0 := 2 * math.Pi * f0
+	wd := w0 * math.Sqrt(1-zeta*zeta)
+	p := complex(-zeta*w0, wd)
+	analog := ZPK{P: []complex128{p, cmplx.Conj(p)}, K: w0 * w0}
+	step := func(t float64) float64 {
+		return 1 - math.Exp(-zeta*w0*t)*(math.Cos(wd*t)+zeta/math.Sqrt(1-zeta*zeta)*math.Sin(wd*t))
+	}
+	impulse := func(t float64) float64 {
+		return w0 / math.Sqrt(1-zeta*zeta) * math.Exp(-zeta*w0*t) * math.Sin(wd*t)
+	}
+	methods := []struct {
+		name   string
+		method Discretization
+	}{
+		{"Tustin", Tustin},
+		{"ZOH", ZOH},
+		{"ForwardEuler", ForwardEuler},
+		{"BackwardEuler", BackwardEuler},
+		{"MatchedZ", MatchedZ},
+		{"ImpulseInvariant", ImpulseInvariant},
+	}
+	freqs := []float64{0, 5, f0, 200, 400}
+	relErr := make(map[Discretization][]float64)
+	for _, m := range methods {
+		d, err := Discretize(analog, fs, m.method)
+		if err != nil {
+			t.Fatal(m.name, err)
+		}
+		H := d.getH()
+		for _, f := range freqs {
+			ha := analog.eval(complex(0, 2*math.Pi*f))
+			hd := H(cmplx.Rect(1, 2*math.Pi*f/fs))
+			relErr[m.method] = append(relErr[m.method], cmplx.Abs(hd-ha)/cmplx.Abs(ha))
+		}
+		t.Logf("%-16s relative error at %v Hz: %.3g", m.name, freqs, relErr[m.method])
+	}
+	for _, m := range methods {
+		if m.method != ImpulseInvariant && relErr[m.method][0] > 1e-9 {
+			t.Errorf("%s: expected exact DC gain, got relative error %g", m.name, relErr[m.method][0])
+		}
+	}
+	// Euler methods diverge the most around the resonance.
+	for _, m := range methods {
+		if m.method == ForwardEuler || m.method == BackwardEuler {
+			continue
+		}
+		if e := relErr[m.method][2]; e >= relErr[ForwardEuler][2] || e >= relErr[BackwardEuler][2] {
+			t.Errorf("%s: expected smaller error at resonance than Euler methods, got %g", m.name, e)
+		}
+	}
+
+	// ZOH matches the analog step response at the sampling instants.
+	d, _ := Discretize(analog, fs, ZOH)
+	for n := 0; n < 100; n++ {
+		d.DiscreteProcess(1)
+		if got, want := d.YNext(), step(float64(n)/fs); math.Abs(got-want) > 1e-9 {
+			t.Fatalf("ZOH step sample %d: expected %g, got %g", n, want, got)
+		}
+	}
+	// Impulse invariance matches the scaled analog impulse response.
+	d, _ = Discretize(analog, fs, ImpulseInvariant)
+	for n := 0; n < 100; n++ {
+		x := 0.
+		if n == 0 {
+			x = 1
+		}
+		d.DiscreteProcess(x)
+		if got, want := d.YNext(), impulse(float64(n)/fs)/fs; math.Abs(got-want) > 1e-9 {
+			t.Fatalf("impulse invariant sample %d: expected %g, got %g", n, want, got)
+		}
+	}
+
+	// Matched Z gain of a high pass (zeros at the origin) is matched at Fs/4.
+	hp := ZPK{Z: []complex128{0, 0}, P: analog.P, K: 1}
+	d, err := Discretize(hp, fs, MatchedZ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, w := cmplx.Abs(d.getH()(1i)), cmplx.Abs(hp.eval(complex(0, math.Pi*fs/2))); math.Abs(g-w) > 1e-9 {
+		t.Errorf("matched z high pass: expected gain %g at Fs/4, got %g", w, g)
+	}
+	if _, err := Discretize(hp, fs, ImpulseInvariant); err != ErrNotStrict {
+		t.Errorf("expected ErrNotStrict, got %v", err)
+	}
+	double := ZPK{P: []complex128{-10, -10}, K: 100}
+	if _, err := Discretize(double, fs, ZOH); err != ErrRepeatedPoles {
+		t.Errorf("expected ErrRepeatedPoles, got %v", err)
+	}
+}
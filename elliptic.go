@@ -0,0 +1,245 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Elliptic (Cauer) filters have equiripple passband and stopband. For a given order
+// they have the sharpest transition band of all filters presented in this package.
+// The gain of the normalized low pass filter is
+//  |H(jw)|^2 = 1 / (1 + ep^2 * Rn(w, k)^2)
+// where Rn is the elliptic rational function of order n, ep the passband ripple factor
+// and k the selectivity factor (ratio between passband and stopband edges).
+// The design follows S. J. Orfanidis, "Lecture Notes on Elliptic Filter Design" (2006):
+//  ep = sqrt(10^(rp/10) - 1)   es = sqrt(10^(rs/10) - 1)   k1 = ep/es
+//  k is obtained by solving the degree equation n*K'(k)/K(k) = K'(k1)/K(k1)
+// The zeros and poles for i = 1, 2, ..., floor(n/2) and u_i = (2*i-1)/n are
+//  z_i = j / (k * cd(u_i*K, k))
+//  p_i = j * cd((u_i - j*v0)*K, k)
+//  v0  = -j * asn(j/ep, k1) / n
+// with an extra real pole p_0 = j*sn(j*v0*K, k) for odd orders.
+// Jacobi elliptic functions are evaluated through descending Landen transformations.
+
+// NewEllipticLP creates a low pass elliptic filter from
+//  Fs: sampling frequency
+//  fp: passband edge frequency, where the gain last reaches -rp dB.
+//  rp: peak to peak passband ripple in decibels.
+//  rs: minimum stopband attenuation in decibels.
+//  n: filter order
+func NewEllipticLP(Fs, fp, rp, rs float64, n int) (*SOS, error) {
+	switch {
+	case n < 1:
+		return nil, ErrBadOrder
+	case rp <= 0 || rs <= rp:
+		return nil, ErrBadRipple
+	case fp <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	case fp >= Fs/2:
+		return nil, ErrAboveNyquist
+	}
+	wp := prewarp(Fs, fp)
+	return ellipticPrototype(n, rp, rs).LPToLP(wp).bilinear(Fs).sos(), nil
+}
+
+// NewEllipticHP creates a high pass elliptic filter from
+//  Fs: sampling frequency
+//  fp: passband edge frequency, where the gain first reaches -rp dB.
+//  rp: peak to peak passband ripple in decibels.
+//  rs: minimum stopband attenuation in decibels.
+//  n: filter order
+func NewEllipticHP(Fs, fp, rp, rs float64, n int) (*SOS, error) {
+	switch {
+	case n < 1:
+		return nil, ErrBadOrder
+	case rp <= 0 || rs <= rp:
+		return nil, ErrBadRipple
+	case fp <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	case fp >= Fs/2:
+		return nil, ErrAboveNyquist
+	}
+	wp := prewarp(Fs, fp)
+	return ellipticPrototype(n, rp, rs).LPToHP(wp).bilinear(Fs).sos(), nil
+}
+
+// NewEllipticBP creates a band pass elliptic filter from
+//  Fs: sampling frequency
+//  f1: lower passband edge frequency, where the gain first reaches -rp dB.
+//  f2: upper passband edge frequency, where the gain last reaches -rp dB.
+//  rp: peak to peak passband ripple in decibels.
+//  rs: minimum stopband attenuation in decibels.
+//  n: order of the low pass prototype. The resulting filter is of order 2*n.
+func NewEllipticBP(Fs, f1, f2, rp, rs float64, n int) (*SOS, error) {
+	switch {
+	case n < 1:
+		return nil, ErrBadOrder
+	case rp <= 0 || rs <= rp:
+		return nil, ErrBadRipple
+	case f1 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	case f2 <= f1:
+		return nil, ErrNegBandwidth
+	case f2 >= Fs/2:
+		return nil, ErrAboveNyquist
+	}
+	w1, w2 := prewarp(Fs, f1), prewarp(Fs, f2)
+	return ellipticPrototype(n, rp, rs).LPToBP(math.Sqrt(w1*w2), w2-w1).bilinear(Fs).sos(), nil
+}
+
+// NewEllipticBS creates a band stop elliptic filter from
+//  Fs: sampling frequency
+//  f1: lower passband edge frequency, where the gain last reaches -rp dB.
+//  f2: upper passband edge frequency, where the gain first reaches -rp dB.
+//  rp: peak to peak passband ripple in decibels.
+//  rs: minimum stopband attenuation in decibels.
+//  n: order of the low pass prototype. The resulting filter is of order 2*n.
+func NewEllipticBS(Fs, f1, f2, rp, rs float64, n int) (*SOS, error) {
+	switch {
+	case n < 1:
+		return nil, ErrBadOrder
+	case rp <= 0 || rs <= rp:
+		return nil, ErrBadRipple
+	case f1 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	case f2 <= f1:
+		return nil, ErrNegBandwidth
+	case f2 >= Fs/2:
+		return nil, ErrAboveNyquist
+	}
+	w1, w2 := prewarp(Fs, f1), prewarp(Fs, f2)
+	return ellipticPrototype(n, rp, rs).LPToBS(math.Sqrt(w1*w2), w2-w1).bilinear(Fs).sos(), nil
+}
+
+// EllipticPrototype returns the analog low pass elliptic prototype of order n,
+// passband ripple rp (dB) and minimum stopband attenuation rs (dB)
+// with passband edge at 1 rad/s. The stopband edge is at 1/k rad/s where k
+// is the selectivity returned by ellipdeg.
+// Invalid parameters return ErrBadOrder or ErrBadRipple.
+func EllipticPrototype(n int, rp, rs float64) (ZPK, error) {
+	switch {
+	case n < 1:
+		return ZPK{}, ErrBadOrder
+	case rp <= 0 || rs <= rp:
+		return ZPK{}, ErrBadRipple
+	}
+	return ellipticPrototype(n, rp, rs), nil
+}
+
+// ellipticPrototype is EllipticPrototype without parameter validation.
+func ellipticPrototype(n int, rp, rs float64) ZPK {
+	ep := math.Sqrt(math.Pow(10, rp/10) - 1)
+	es := math.Sqrt(math.Pow(10, rs/10) - 1)
+	k1 := ep / es
+	k := ellipdeg(n, k1)
+	N := float64(n)
+	v0 := -1i * asne(complex(0, 1/ep), k1) / complex(N, 0)
+	z := make([]complex128, 0, n)
+	p := make([]complex128, 0, n)
+	for i := 1; i <= n/2; i++ {
+		u := float64(2*i-1) / N
+		zeta := real(cde(complex(u, 0), k))
+		z = append(z, complex(0, 1/(k*zeta)), complex(0, -1/(k*zeta)))
+		pi := 1i * cde(complex(u, 0)-1i*v0, k)
+		p = append(p, pi, cmplx.Conj(pi))
+	}
+	if n%2 == 1 {
+		p = append(p, complex(real(1i*sne(1i*v0, k)), 0))
+	}
+	g := real(prodNeg(p) / prodNeg(z))
+	if n%2 == 0 {
+		g /= math.Sqrt(1 + ep*ep)
+	}
+	return ZPK{Z: z, P: p, K: g}
+}
+
+// landen returns the descending Landen sequence of elliptic moduli
+//  k_n = (k_{n-1} / (1 + sqrt(1 - k_{n-1}^2)))^2    k_0 = k
+// The sequence converges quadratically to zero and is cut once the modulus is negligible.
+func landen(k float64) []float64 {
+	const tol = 1e-16
+	var v []float64
+	for k > tol && len(v) < 32 {
+		k = k / (1 + math.Sqrt(1-k*k))
+		k *= k
+		v = append(v, k)
+	}
+	return v
+}
+
+// ellipk returns the complete elliptic integral of the first kind K(k)
+// and its complement K'(k) = K(sqrt(1-k^2)).
+func ellipk(k float64) (K, Kprime float64) {
+	kp := math.Sqrt(1 - k*k)
+	return ellipk1(k, kp), ellipk1(kp, k)
+}
+
+// ellipk1 calculates K(k) given the modulus k and its complement kp = sqrt(1-k^2).
+func ellipk1(k, kp float64) float64 {
+	const kmin = 1e-6
+	if kp < kmin {
+		// Asymptotic expansion of K(k) for k near 1.
+		L := -math.Log(kp / 4)
+		return L + (L-1)*kp*kp/4
+	}
+	K := math.Pi / 2
+	for _, v := range landen(k) {
+		K *= 1 + v
+	}
+	return K
+}
+
+// cde evaluates the Jacobi elliptic function cd(u*K, k) where K = K(k).
+func cde(u complex128, k float64) complex128 {
+	v := landen(k)
+	w := cmplx.Cos(u * math.Pi / 2)
+	for n := len(v) - 1; n >= 0; n-- {
+		vn := complex(v[n], 0)
+		w = (1 + vn) * w / (1 + vn*w*w)
+	}
+	return w
+}
+
+// sne evaluates the Jacobi elliptic function sn(u*K, k) where K = K(k).
+func sne(u complex128, k float64) complex128 {
+	v := landen(k)
+	w := cmplx.Sin(u * math.Pi / 2)
+	for n := len(v) - 1; n >= 0; n-- {
+		vn := complex(v[n], 0)
+		w = (1 + vn) * w / (1 + vn*w*w)
+	}
+	return w
+}
+
+// acde evaluates the inverse of cde: returns u such that cd(u*K, k) = w.
+// The real part of u is reduced to [-2, 2] and the imaginary part to [-K'/K, K'/K].
+func acde(w complex128, k float64) complex128 {
+	v := landen(k)
+	v1 := k
+	for n := range v {
+		w = w / (1 + cmplx.Sqrt(1-w*w*complex(v1*v1, 0))) * complex(2/(1+v[n]), 0)
+		v1 = v[n]
+	}
+	u := 2 / math.Pi * cmplx.Acos(w)
+	K, Kprime := ellipk(k)
+	R := Kprime / K
+	return complex(math.Remainder(real(u), 4), math.Remainder(imag(u), 2*R))
+}
+
+// asne evaluates the inverse of sne: returns u such that sn(u*K, k) = w.
+func asne(w complex128, k float64) complex128 {
+	return 1 - acde(w, k)
+}
+
+// ellipdeg solves the degree equation for the selectivity k of an elliptic
+// filter of order n and discrimination factor k1 = ep/es.
+//  k' = k1'^n * PROD(sn(u_i*K', k1'))^4   u_i = (2*i-1)/n, i = 1, 2, ..., floor(n/2)
+func ellipdeg(n int, k1 float64) float64 {
+	k1p := math.Sqrt(1 - k1*k1)
+	kp := math.Pow(k1p, float64(n))
+	for i := 1; i <= n/2; i++ {
+		u := float64(2*i-1) / float64(n)
+		kp *= math.Pow(real(sne(complex(u, 0), k1p)), 4)
+	}
+	return math.Sqrt(1 - kp*kp)
+}
@@ -0,0 +1,109 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestEllipticFunctions(t *testing.T) {
+	const tol = 1e-12
+	// K(1/sqrt(2)) = Gamma(1/4)^2 / (4*sqrt(pi))
+	if K, Kp := ellipk(math.Sqrt2 / 2); math.Abs(K-1.8540746773013719) > tol || math.Abs(K-Kp) > tol {
+		t.Errorf("K(1/sqrt(2)): expected 1.8540746773013719, got K=%g K'=%g", K, Kp)
+	}
+	if K, _ := ellipk(0); math.Abs(K-math.Pi/2) > tol {
+		t.Errorf("K(0): expected pi/2, got %g", K)
+	}
+	for _, k := range []float64{0.1, 0.5, 0.9, 0.999} {
+		for _, u := range []complex128{0.1, 0.5, 0.3 + 0.2i, 0.7 - 0.1i} {
+			// sn^2 + cn^2 = 1 and cd(u) = sn(1-u).
+			if got, want := cde(u, k), sne(1-u, k); cmplx.Abs(got-want) > tol {
+				t.Errorf("k=%g u=%v: cd(u) != sn(1-u): %v != %v", k, u, got, want)
+			}
+			if got := cde(acde(cde(u, k), k), k); cmplx.Abs(got-cde(u, k)) > 1e-9 {
+				t.Errorf("k=%g u=%v: acde is not the inverse of cde", k, u)
+			}
+			if got := sne(asne(sne(u, k), k), k); cmplx.Abs(got-sne(u, k)) > 1e-9 {
+				t.Errorf("k=%g u=%v: asne is not the inverse of sne", k, u)
+			}
+		}
+	}
+}
+
+func TestElliptic(t *testing.T) {
+	const (
+		fs = 1e3
+		fp = 100.
+		f1 = 100.
+		f2 = 200.
+	)
+	dB := func(s *SOS, f float64) float64 {
+		return 20 * math.Log10(cmplx.Abs(s.getH()(cmplx.Rect(1, 2*math.Pi*f/fs))))
+	}
+	// extrema returns the maximum and minimum gains between frequencies lo and hi.
+	extrema := func(s *SOS, lo, hi float64) (min, max float64) {
+		min, max = math.Inf(1), math.Inf(-1)
+		for i := 0; i <= 2000; i++ {
+			g := dB(s, lo+(hi-lo)*float64(i)/2000)
+			min, max = math.Min(min, g), math.Max(max, g)
+		}
+		return min, max
+	}
+	for _, rp := range []float64{0.1, 1} {
+		for _, rs := range []float64{30, 60} {
+			ep := math.Sqrt(math.Pow(10, rp/10) - 1)
+			es := math.Sqrt(math.Pow(10, rs/10) - 1)
+			for n := 1; n <= 7; n++ {
+				lp, err := NewEllipticLP(fs, fp, rp, rs, n)
+				if err != nil {
+					t.Fatal(err)
+				}
+				hp, err := NewEllipticHP(fs, fp, rp, rs, n)
+				if err != nil {
+					t.Fatal(err)
+				}
+				bp, err := NewEllipticBP(fs, f1, f2, rp, rs, n)
+				if err != nil {
+					t.Fatal(err)
+				}
+				bs, err := NewEllipticBS(fs, f1, f2, rp, rs, n)
+				if err != nil {
+					t.Fatal(err)
+				}
+				// Stopband edge of the low pass is at wp/k in the pre-warped analog domain.
+				k := ellipdeg(n, ep/es)
+				fst := fs / math.Pi * math.Atan(math.Tan(math.Pi*fp/fs)/k)
+				fsth := fs / math.Pi * math.Atan(math.Tan(math.Pi*fp/fs)*k)
+				if fst >= fs/2-1 || fsth < 1 {
+					continue // Stopband is too narrow to test.
+				}
+				for _, test := range []struct {
+					name    string
+					s       *SOS
+					lo, hi  float64
+					passing bool
+				}{
+					{"LP passband", lp, 0, fp, true},
+					{"LP stopband", lp, fst, fs / 2, false},
+					{"HP passband", hp, fp, fs / 2, true},
+					{"HP stopband", hp, 0, fsth, false},
+					{"BP passband", bp, f1, f2, true},
+					{"BS passband low", bs, 0, f1, true},
+					{"BS passband high", bs, f2, fs / 2, true},
+				} {
+					min, max := extrema(test.s, test.lo, test.hi)
+					switch {
+					case test.passing && (max > 1e-9 || max < -1e-3 || min < -rp-1e-9 || min > -rp+1e-3):
+						t.Errorf("rp=%g rs=%g n=%d %s: expected ripple in [-%g, 0]dB, got [%g, %g]", rp, rs, n, test.name, rp, min, max)
+					case !test.passing && math.Abs(max+rs) > 1e-3:
+						t.Errorf("rp=%g rs=%g n=%d %s: expected floor at -%gdB, got %gdB", rp, rs, n, test.name, rs, max)
+					}
+				}
+				if got := dB(lp, fp); math.Abs(got+rp) > 1e-9 {
+					t.Errorf("rp=%g rs=%g n=%d: expected -%gdB at passband edge, got %gdB", rp, rs, n, rp, got)
+				}
+			}
+		}
+	}
+}
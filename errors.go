@@ -3,9 +3,25 @@ package biquad
 import "errors"
 
 var (
-	ErrShortXY        = errors.New("XYer length must be greater than 2 to apply BLT filter")
-	ErrBadWorkingFreq = errors.New("working frequency can not be higher than sampling frequency")
-	ErrNegBandwidth   = errors.New("bandwidth must be greater than zero")
-	ErrBadFreq        = errors.New("zero or negative frequency")
-	ErrBadGain        = errors.New("negative or zero gain")
+	ErrShortXY         = errors.New("XYer length must be greater than 2 to apply BLT filter")
+	ErrBadWorkingFreq  = errors.New("working frequency can not be higher than sampling frequency")
+	ErrNegBandwidth    = errors.New("bandwidth must be greater than zero")
+	ErrBadFreq         = errors.New("zero or negative frequency")
+	ErrBadGain         = errors.New("negative or zero gain")
+	ErrBadQ            = errors.New("quality factor must be greater than zero")
+	ErrBadOrder        = errors.New("filter order must be greater than zero")
+	ErrOddOrder        = errors.New("filter order must be even and greater than zero")
+	ErrAboveNyquist    = errors.New("frequency must be lower than the Nyquist frequency (Fs/2)")
+	ErrBadRipple       = errors.New("ripple or attenuation must be greater than zero")
+	ErrBadCoefficients = errors.New("leading denominator coefficient a0 can not be zero")
+	ErrBiquadOrder     = errors.New("biquad can not have more than two zeros or poles")
+	ErrImproperZPK     = errors.New("transfer function can not have more zeros than poles")
+	ErrNotConjugate    = errors.New("complex zeros and poles must come in conjugate pairs")
+	ErrBadSlope        = errors.New("slope must be greater than zero and yield a real alpha")
+	ErrRepeatedPoles   = errors.New("discretization method requires distinct poles")
+	ErrNotStrict       = errors.New("transfer function must have more poles than zeros")
+	ErrBadMethod       = errors.New("unknown discretization method")
+	ErrBadFamily       = errors.New("unknown filter family")
+	ErrBadTolerance    = errors.New("tolerance must be greater than zero")
+	ErrStateLength     = errors.New("state length must match the number of sections or filters")
 )
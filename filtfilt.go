@@ -0,0 +1,87 @@
+package biquad
+
+// FiltFilt applies the biquad to a digital signal twice, once forward and once
+// backward, and returns the filtered result. The result has zero phase distortion
+// and a gain equal to the squared magnitude response of the filter.
+// As done by scipy's filtfilt the signal is extended at both ends by an odd
+// reflection of 3*(2*sections+1) samples and each pass starts from steady state
+// initial conditions scaled by its first sample, which reduces transients at the edges.
+// Unlike scipy, which returns an error when the signal is not longer than the
+// padding, shorter signals are extended by len(signal)-1 samples instead.
+// The state of the filter is not modified. The length of the data must be greater than 2.
+//
+// Cascade and Bank have no FiltFilt method: they may hold arbitrary RecursiveFilters
+// which can not be copied nor initialized at steady state. Design the equivalent
+// filter as an SOS to filter with zero phase.
+func (b *blt) FiltFilt(signal Signal) (Signal, error) {
+	return filtfilt([]blt{*b}, signal)
+}
+
+// FiltFilt applies the cascade to a digital signal twice, once forward and once
+// backward, and returns the zero phase filtered result. See the FiltFilt method
+// of the biquad filters for details. The state of the filter is not modified.
+func (s *SOS) FiltFilt(signal Signal) (Signal, error) {
+	return filtfilt(append([]blt{}, s.sections...), signal)
+}
+
+// filtfilt runs the sections forward and backward over an oddly extended signal.
+// The padding is clamped to N-1 samples for short signals. The sections are modified.
+func filtfilt(sections []blt, signal Signal) (Signal, error) {
+	N := signal.Len()
+	if N < 3 {
+		return nil, ErrShortXY
+	}
+	pad := 3 * (2*len(sections) + 1)
+	if pad > N-1 {
+		pad = N - 1
+	}
+	x := make([]float64, N)
+	for i := range x {
+		_, x[i] = signal.XY(i)
+	}
+	// Odd extension: reflect the signal about its end points.
+	ext := make([]float64, 0, N+2*pad)
+	for i := pad; i > 0; i-- {
+		ext = append(ext, 2*x[0]-x[i])
+	}
+	ext = append(ext, x...)
+	for i := N - 2; i >= N-1-pad; i-- {
+		ext = append(ext, 2*x[N-1]-x[i])
+	}
+	lfilter(sections, ext)
+	reverse(ext)
+	lfilter(sections, ext)
+	reverse(ext)
+	return filtered{
+		Signal: signal,
+		fval:   ext[pad : pad+N],
+	}, nil
+}
+
+// lfilter filters x in place through the sections, starting from steady state
+// initial conditions for a constant input x[0].
+func lfilter(sections []blt, x []float64) {
+	setSteadyState(sections, x[0])
+	for i := range x {
+		v := x[i]
+		for j := range sections {
+			sections[j].advance(v)
+			v = sections[j].ynext()
+		}
+		x[i] = v
+	}
+}
+
+// setSteadyState sets the state of the cascade of sections to that of
+// a constant input x0 applied for an infinite time.
+func setSteadyState(sections []blt, x0 float64) {
+	for i := range sections {
+		x0 = sections[i].steadyState(x0)
+	}
+}
+
+func reverse(x []float64) {
+	for i, j := 0, len(x)-1; i < j; i, j = i+1, j-1 {
+		x[i], x[j] = x[j], x[i]
+	}
+}
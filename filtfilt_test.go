@@ -0,0 +1,107 @@
+package biquad
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFiltFilt(t *testing.T) {
+	const (
+		fs = 1e3
+		fc = 100.
+		N  = 1000
+	)
+	lp, err := NewButterworthLPN(fs, fc, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hp, err := NewHighPass(fs, fc, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	constant := make([]float64, N)
+	for i := range constant {
+		constant[i] = 5
+	}
+	// Steady state initial conditions: no transients at the edges.
+	for _, test := range []struct {
+		name string
+		ff   func(Signal) (Signal, error)
+		want float64
+	}{
+		{"low pass", lp.FiltFilt, 5},
+		{"high pass", hp.FiltFilt, 0},
+	} {
+		got, err := test.ff(MakeSignal(fs, constant))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < got.Len(); i++ {
+			if _, y := got.XY(i); math.Abs(y-test.want) > 1e-9 {
+				t.Fatalf("%s: expected constant %g, got %g at sample %d", test.name, test.want, y, i)
+			}
+		}
+	}
+
+	// Zero phase: a sinusoid in the passband is scaled by |H|^2 with no delay.
+	const f = 20.
+	g := math.Pow(10, lp.MagnitudeDB(fs, []float64{f})[0]/10)
+	sine := make([]float64, N)
+	for i := range sine {
+		sine[i] = math.Sin(2 * math.Pi * f * float64(i) / fs)
+	}
+	got, err := lp.FiltFilt(MakeSignal(fs, sine))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range sine {
+		tol := 1e-6
+		if i < 50 || i >= N-50 {
+			tol = 5e-3 // Edge effects of the odd extension.
+		}
+		if _, y := got.XY(i); math.Abs(y-g*sine[i]) > tol {
+			t.Fatalf("expected %g at sample %d, got %g", g*sine[i], i, y)
+		}
+	}
+
+	// A pulse keeps its position while forward filtering delays it.
+	pulse := make([]float64, N)
+	for i := range pulse {
+		pulse[i] = math.Exp(-math.Pow(float64(i-N/2)/20, 2))
+	}
+	argmax := func(s Signal) int {
+		imax, ymax := 0, math.Inf(-1)
+		for i := 0; i < s.Len(); i++ {
+			if _, y := s.XY(i); y > ymax {
+				imax, ymax = i, y
+			}
+		}
+		return imax
+	}
+	zp, err := lp.FiltFilt(MakeSignal(fs, pulse))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fwd, err := lp.Filter(MakeSignal(fs, pulse))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := argmax(zp); got != N/2 {
+		t.Errorf("expected zero phase peak at sample %d, got %d", N/2, got)
+	}
+	if got := argmax(fwd); got <= N/2 {
+		t.Errorf("expected forward filtered peak to lag sample %d, got %d", N/2, got)
+	}
+
+	// Short signals are padded with as many samples as available.
+	short, err := lp.FiltFilt(MakeSignal(fs, constant[:5]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, y := short.XY(4); math.Abs(y-5) > 1e-9 {
+		t.Errorf("expected short constant signal to pass, got %g", y)
+	}
+	if _, err := lp.FiltFilt(MakeSignal(fs, constant[:2])); err != ErrShortXY {
+		t.Errorf("expected ErrShortXY, got %v", err)
+	}
+}
@@ -20,8 +20,30 @@ func NewHighPass(Fs, f0, BW float64) (*HighPass, error) {
 		return nil, ErrBadFreq
 	}
 	w0 := 2 * math.Pi * (f0 / Fs)
-	cos := math.Cos(w0)
 	alpha := alphaCalc{}.bw(w0, BW)
+	return newHighPass(w0, alpha), nil
+}
+
+// NewHighPassQ creates a high pass filter from
+//  Fs: sampling frequency
+//  f0: working frequency
+//  Q: quality factor. Q = 1/sqrt(2) yields a maximally flat (Butterworth) response.
+func NewHighPassQ(Fs, f0, Q float64) (*HighPass, error) {
+	switch {
+	case f0 >= Fs:
+		return nil, ErrBadWorkingFreq
+	case Q <= 0:
+		return nil, ErrBadQ
+	case f0 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	}
+	w0 := 2 * math.Pi * (f0 / Fs)
+	alpha := alphaCalc{}.q(w0, Q)
+	return newHighPass(w0, alpha), nil
+}
+
+func newHighPass(w0, alpha float64) *HighPass {
+	cos := math.Cos(w0)
 	var (
 		b0 = (1 + cos) / 2
 		b1 = -(1 + cos)
@@ -32,5 +54,5 @@ func NewHighPass(Fs, f0, BW float64) (*HighPass, error) {
 	)
 	return &HighPass{
 		blt: newBLT(a0, a1, a2, b0, b1, b2),
-	}, nil
+	}
 }
@@ -0,0 +1,103 @@
+package biquad
+
+import "math"
+
+// InitMode selects the initial state of a filter when calling Filter.
+type InitMode int
+
+const (
+	// InitSteadyState initializes the filter to the state reached after the first
+	// sample of the signal has been applied for an infinite time, as done by
+	// scipy's lfilter_zi. Filtering a constant signal yields no transient.
+	// This is the default.
+	InitSteadyState InitMode = iota
+	// InitZero initializes the filter at rest: all past inputs and outputs are zero.
+	InitZero
+	// InitCustom initializes the filter to the state set with SetInitialConditions.
+	InitCustom
+)
+
+// SetInit sets the initial condition mode of the biquad used by Filter.
+func (b *blt) SetInit(mode InitMode) { b.initMode = mode }
+
+// SetInitialConditions sets custom initial conditions for Filter and
+// sets the initial condition mode to InitCustom.
+//  x: past inputs x[n-1] and x[n-2]
+//  y: past outputs y[n-1] and y[n-2]
+func (b *blt) SetInitialConditions(x, y [2]float64) {
+	b.initMode = InitCustom
+	b.xi, b.yi = x, y
+}
+
+// SetSteadyState sets the current state of the biquad to the steady state of a
+// constant input x0. It is useful to start processing samples with DiscreteProcess
+// without a transient. Filters with a pole at z=1 (infinite DC gain) are set to zero state.
+func (b *blt) SetSteadyState(x0 float64) { b.steadyState(x0) }
+
+// steadyState sets the state of the biquad to that of a constant input x0
+// applied for an infinite time and returns the steady state output. In Direct Form I
+// the state is the input and output history so these are set to x0 and DC gain*x0.
+func (b *blt) steadyState(x0 float64) (y0 float64) {
+	g := b.dcGain()
+	if math.IsInf(g, 0) || math.IsNaN(g) {
+		x0, g = 0, 0
+	}
+	y0 = g * x0
+	b.setHistory([2]float64{x0, x0}, [2]float64{y0, y0})
+	return y0
+}
+
+// setHistory sets past inputs x[n-1], x[n-2] and past outputs y[n-1], y[n-2].
+func (b *blt) setHistory(x, y [2]float64) {
+	for k := uint(1); k <= 2; k++ {
+		i := (b.ptr - k) % 3
+		b.x[i], b.y[i] = x[k-1], y[k-1]
+	}
+}
+
+// dcGain returns the gain of the biquad at DC, H(z=1).
+func (b *blt) dcGain() float64 {
+	return (b.b0d + b.b1d + b.b2d) / (1 + b.a1d + b.a2d)
+}
+
+// SetInit sets the initial condition mode of the cascade used by Filter.
+func (s *SOS) SetInit(mode InitMode) { s.initMode = mode }
+
+// SetInitialConditions sets custom initial conditions of each section
+// for Filter and sets the initial condition mode to InitCustom.
+//  x: past inputs x[n-1] and x[n-2] of each section.
+//  y: past outputs y[n-1] and y[n-2] of each section.
+// The input of a section is the output of the previous section.
+// The length of x and y must match the number of sections.
+func (s *SOS) SetInitialConditions(x, y [][2]float64) error {
+	if len(x) != len(s.sections) || len(y) != len(s.sections) {
+		return ErrStateLength
+	}
+	s.initMode = InitCustom
+	for i := range s.sections {
+		s.sections[i].xi, s.sections[i].yi = x[i], y[i]
+	}
+	return nil
+}
+
+// SetSteadyState sets the current state of the cascade to the steady state of a
+// constant input x0. See the SetSteadyState method of the biquad filters.
+func (s *SOS) SetSteadyState(x0 float64) { setSteadyState(s.sections, x0) }
+
+// init initializes the state of the sections before filtering the signal
+// according to the initial condition mode of the cascade.
+func (s *SOS) init(xy Signal) {
+	switch s.initMode {
+	case InitZero:
+		for i := range s.sections {
+			s.sections[i].setHistory([2]float64{}, [2]float64{})
+		}
+	case InitCustom:
+		for i := range s.sections {
+			s.sections[i].setHistory(s.sections[i].xi, s.sections[i].yi)
+		}
+	default:
+		_, x0 := xy.XY(0)
+		setSteadyState(s.sections, x0)
+	}
+}
@@ -0,0 +1,90 @@
+package biquad
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInitialConditions(t *testing.T) {
+	const (
+		fs = 1e3
+		f0 = 100.
+		N  = 50
+	)
+	constant := make([]float64, N)
+	for i := range constant {
+		constant[i] = 5
+	}
+	signal := MakeSignal(fs, constant)
+	hp, _ := NewHighPass(fs, f0, 1)
+	bp, _ := NewBandPass(fs, f0, 1)
+	notch, _ := NewNotch(fs, f0, 1)
+	gain2, _ := NewBiquad(0.2, 0.2, 0, 1, -0.8, 0) // DC gain of 2.
+	sos, _ := NewButterworthHPN(fs, f0, 5)
+	// Steady state initial conditions yield no transient on constant signals.
+	for _, test := range []struct {
+		name string
+		f    interface {
+			Filter(Signal) (Signal, error)
+		}
+		want float64
+	}{
+		{"high pass", hp, 0},
+		{"band pass", bp, 0},
+		{"notch", notch, 5},
+		{"gain of 2", gain2, 10},
+		{"SOS high pass", sos, 0},
+	} {
+		got, err := test.f.Filter(signal)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < N; i++ {
+			if _, y := got.XY(i); math.Abs(y-test.want) > 1e-9 {
+				t.Fatalf("%s: expected %g, got %g at sample %d", test.name, test.want, y, i)
+			}
+		}
+	}
+
+	// Zero initial conditions yield the step response.
+	gain2.SetInit(InitZero)
+	got, _ := gain2.Filter(signal)
+	fresh, _ := NewBiquad(0.2, 0.2, 0, 1, -0.8, 0)
+	step := StepResponse(fresh, fs, N)
+	for i := 0; i < N; i++ {
+		_, y := got.XY(i)
+		_, want := step.XY(i)
+		if math.Abs(y-5*want) > 1e-12 {
+			t.Fatalf("zero state: expected %g, got %g at sample %d", 5*want, y, i)
+		}
+	}
+	sos.SetInit(InitZero)
+	got, _ = sos.Filter(signal)
+	if _, y := got.XY(0); math.Abs(y-5*sos.sections[0].b0d*sos.sections[1].b0d*sos.sections[2].b0d) > 1e-12 {
+		t.Errorf("zero state SOS: expected first sample to be 5*b0 product, got %g", y)
+	}
+
+	// Custom initial conditions.
+	b, _ := NewBiquad(1, 2, 3, 1, 0.5, 0.25)
+	b.SetInitialConditions([2]float64{1, 2}, [2]float64{3, 4})
+	got, _ = b.Filter(signal)
+	if _, y := got.XY(0); y != 5+2*1+3*2-0.5*3-0.25*4 {
+		t.Errorf("custom state: got %g for first sample", y)
+	}
+	if _, y := got.XY(1); y != 5+2*5+3*1-0.5*(5+2+6-1.5-1)-0.25*3 {
+		t.Errorf("custom state: got %g for second sample", y)
+	}
+	if err := sos.SetInitialConditions(make([][2]float64, 2), make([][2]float64, 3)); err != ErrStateLength {
+		t.Errorf("expected ErrStateLength, got %v", err)
+	}
+
+	// Steady state for sample by sample processing.
+	lp, _ := NewButterworthLPN(fs, f0, 4)
+	lp.SetSteadyState(-3)
+	for i := 0; i < N; i++ {
+		lp.DiscreteProcess(-3)
+		if y := lp.YNext(); math.Abs(y+3) > 1e-9 {
+			t.Fatalf("expected steady output of -3, got %g at sample %d", y, i)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Linkwitz-Riley filters are the square of Butterworth filters
+//  Hlp(s) = 1/Bn(s/wc)^2     Hhp(s) = (s/wc)^(2n) / Bn(s/wc)^2
+// The low pass and high pass outputs are -6dB at the crossover frequency and,
+// since Bn(s)*Bn(-s) = 1 + (-1)^n * s^(2n), their sum
+//  Hlp(s) + (-1)^n * Hhp(s) = Bn(-s/wc) / Bn(s/wc)
+// is an all pass filter with flat magnitude. For odd n (LR2, LR6) the high pass
+// output must be inverted for the outputs to sum flat, which is done by NewLinkwitzRiley.
+
+// NewLinkwitzRiley creates a Linkwitz-Riley crossover low pass and high pass pair from
+//  Fs: sampling frequency
+//  fc: crossover frequency, where both outputs are at -6dB gain.
+//  order: crossover order. Must be even, commonly 2 (LR2), 4 (LR4) or 8 (LR8).
+// The sum of the low pass and high pass outputs has unity gain at all frequencies.
+// The high pass polarity is inverted for orders 2, 6, 10... so this holds for all orders.
+func NewLinkwitzRiley(Fs, fc float64, order int) (lp, hp *SOS, err error) {
+	switch {
+	case order < 2 || order%2 != 0:
+		return nil, nil, ErrOddOrder
+	case fc <= 0 || Fs <= 0:
+		return nil, nil, ErrBadFreq
+	case fc >= Fs/2:
+		return nil, nil, ErrAboveNyquist
+	}
+	n := order / 2
+	bw := butterworthPrototype(n)
+	lr := ZPK{P: append(append([]complex128{}, bw.P...), bw.P...), K: bw.K * bw.K}
+	wc := prewarp(Fs, fc)
+	hpz := lr.LPToHP(wc)
+	if n%2 == 1 {
+		hpz.K = -hpz.K
+	}
+	return lr.LPToLP(wc).bilinear(Fs).sos(), hpz.bilinear(Fs).sos(), nil
+}
+
+// CrossoverSumDB returns the gain in decibels of the sum of the low pass and high pass
+// outputs of a crossover at frequencies f (in Hz) for a sampling frequency Fs.
+// A properly matched crossover such as a Linkwitz-Riley pair yields 0dB at all frequencies.
+func CrossoverSumDB(Fs float64, lp, hp *SOS, f []float64) []float64 {
+	Hlp, Hhp := lp.getH(), hp.getH()
+	db := make([]float64, len(f))
+	for i := range f {
+		z := cmplx.Rect(1, 2*math.Pi*f[i]/Fs)
+		db[i] = 20 * math.Log10(cmplx.Abs(Hlp(z)+Hhp(z)))
+	}
+	return db
+}
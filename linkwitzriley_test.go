@@ -0,0 +1,57 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestLinkwitzRiley(t *testing.T) {
+	const (
+		fs = 48e3
+		fc = 2e3
+	)
+	freqs := []float64{10, 100, 500, 1e3, fc, 3e3, 8e3, 15e3, 23e3}
+	for _, order := range []int{2, 4, 6, 8} {
+		lp, hp, err := NewLinkwitzRiley(fs, fc, order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, db := range CrossoverSumDB(fs, lp, hp, freqs) {
+			if math.Abs(db) > 1e-9 {
+				t.Errorf("LR%d: expected flat sum, got %gdB at %gHz", order, db, freqs[i])
+			}
+		}
+		z := cmplx.Rect(1, 2*math.Pi*fc/fs)
+		for name, f := range map[string]*SOS{"LP": lp, "HP": hp} {
+			if got := 20 * math.Log10(cmplx.Abs(f.getH()(z))); math.Abs(got-20*math.Log10(0.5)) > 1e-9 {
+				t.Errorf("LR%d %s: expected -6dB at crossover, got %gdB", order, name, got)
+			}
+		}
+	}
+	// LR4 is two cascaded second order Butterworth filters.
+	lp, hp, err := NewLinkwitzRiley(fs, fc, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blp, err := NewButterworthLP(fs, fc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bhp, err := NewButterworthHP(fs, fc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range freqs {
+		z := cmplx.Rect(1, 2*math.Pi*f/fs)
+		if got, want := lp.getH()(z), cmplx.Pow(blp.getH()(z), 2); cmplx.Abs(got-want) > 1e-9 {
+			t.Errorf("LR4 LP at %gHz: expected %v, got %v", f, want, got)
+		}
+		if got, want := hp.getH()(z), cmplx.Pow(bhp.getH()(z), 2); cmplx.Abs(got-want) > 1e-9 {
+			t.Errorf("LR4 HP at %gHz: expected %v, got %v", f, want, got)
+		}
+	}
+	if _, _, err := NewLinkwitzRiley(fs, fc, 3); err != ErrOddOrder {
+		t.Errorf("expected ErrOddOrder, got %v", err)
+	}
+}
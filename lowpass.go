@@ -22,8 +22,30 @@ func NewLowPass(Fs, f0, BW float64) (*LowPass, error) {
 		return nil, ErrBadFreq
 	}
 	w0 := 2 * math.Pi * (f0 / Fs)
-	cos := math.Cos(w0)
 	alpha := alphaCalc{}.bw(w0, BW)
+	return newLowPass(w0, alpha), nil
+}
+
+// NewLowPassQ creates a low pass filter from
+//  Fs: sampling frequency
+//  f0: working frequency
+//  Q: quality factor. Q = 1/sqrt(2) yields a maximally flat (Butterworth) response.
+func NewLowPassQ(Fs, f0, Q float64) (*LowPass, error) {
+	switch {
+	case f0 >= Fs:
+		return nil, ErrBadWorkingFreq
+	case Q <= 0:
+		return nil, ErrBadQ
+	case f0 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	}
+	w0 := 2 * math.Pi * (f0 / Fs)
+	alpha := alphaCalc{}.q(w0, Q)
+	return newLowPass(w0, alpha), nil
+}
+
+func newLowPass(w0, alpha float64) *LowPass {
+	cos := math.Cos(w0)
 	var (
 		b0 = (1 - cos) / 2
 		b1 = 1 - cos
@@ -34,5 +56,5 @@ func NewLowPass(Fs, f0, BW float64) (*LowPass, error) {
 	)
 	return &LowPass{
 		blt: newBLT(a0, a1, a2, b0, b1, b2),
-	}, nil
+	}
 }
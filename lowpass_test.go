@@ -61,6 +61,10 @@ func TestFilter(t *testing.T) {
 		t.Fatal(err)
 	}
 	lp.Filter(s2)
+	s1zerophase, err := lp.FiltFilt(s1)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	p := plot.New()
 	p.Legend = plot.NewLegend()
@@ -76,9 +80,17 @@ func TestFilter(t *testing.T) {
 		t.Error(err)
 	}
 
-	p.Add(l1, l1f)
+	// zero phase filtered signal
+	l1zp, err := plotter.NewLine(s1zerophase)
+	if err != nil {
+		t.Error(err)
+	}
+	l1zp.Color = color.RGBA{B: 255, A: 255}
+
+	p.Add(l1, l1f, l1zp)
 	p.Legend.Add("Original signal [red]", l1)
 	p.Legend.Add("Filtered Signal", l1f)
+	p.Legend.Add("Zero phase filtered signal [blue]", l1zp)
 	cm := font.Centimeter
 	p.X.Max = 5
 	p.Y.Max = -350
@@ -100,3 +112,28 @@ func (d *dData) XY(i int) (t, y float64) {
 	return d.ts * float64(i), d.v[i]
 }
 func (d *dData) Len() int { return len(d.v) }
+
+func TestYNextCurrentSample(t *testing.T) {
+	lp, err := NewLowPass(1e3, 100, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The impulse response of the difference equation is
+	//  h[0] = b0, h[1] = b1 - a1*b0, h[2] = b2 - a1*h[1] - a2*b0.
+	h0 := lp.b0d
+	h1 := lp.b1d - lp.a1d*h0
+	h2 := lp.b2d - lp.a1d*h1 - lp.a2d*h0
+	for n, want := range []float64{h0, h1, h2} {
+		x := 0.
+		if n == 0 {
+			x = 1
+		}
+		lp.DiscreteProcess(x)
+		// YNext returns the output for the last processed sample, not a delayed one.
+		for i := 0; i < 2; i++ {
+			if got := lp.YNext(); got != want {
+				t.Fatalf("sample %d: expected %g, got %g", n, want, got)
+			}
+		}
+	}
+}
@@ -22,8 +22,30 @@ func NewNotch(Fs, f0, BW float64) (*Notch, error) {
 		return nil, ErrBadFreq
 	}
 	w0 := 2 * math.Pi * (f0 / Fs)
-	cos := math.Cos(w0)
 	alpha := alphaCalc{}.bw(w0, BW)
+	return newNotch(w0, alpha), nil
+}
+
+// NewNotchQ creates a notch filter from
+//  Fs: sampling frequency
+//  f0: Notched frequency (will be filtered out)
+//  Q: quality factor. Higher Q yields a narrower notch.
+func NewNotchQ(Fs, f0, Q float64) (*Notch, error) {
+	switch {
+	case f0 >= Fs:
+		return nil, ErrBadWorkingFreq
+	case Q <= 0:
+		return nil, ErrBadQ
+	case f0 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	}
+	w0 := 2 * math.Pi * (f0 / Fs)
+	alpha := alphaCalc{}.q(w0, Q)
+	return newNotch(w0, alpha), nil
+}
+
+func newNotch(w0, alpha float64) *Notch {
+	cos := math.Cos(w0)
 	var (
 		b0 = 1.
 		b1 = -2 * cos
@@ -34,5 +56,5 @@ func NewNotch(Fs, f0, BW float64) (*Notch, error) {
 	)
 	return &Notch{
 		blt: newBLT(a0, a1, a2, b0, b1, b2),
-	}, nil
+	}
 }
@@ -0,0 +1,230 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Order estimation works on the pre-warped analog frequencies W = tan(pi*f/Fs),
+// which is how the bilinear designs of this package map frequencies. A
+// low pass specification has fp < fs and a high pass specification fp > fs.
+// With the selectivity factor k = Wp/Ws (low pass) or Ws/Wp (high pass) and
+// the discrimination factor d = sqrt((10^(rp/10) - 1) / (10^(rs/10) - 1)),
+// the minimum orders are
+//  Butterworth:   n = log(1/d) / log(1/k)
+//  Chebyshev:     n = acosh(1/d) / acosh(1/k)
+//  Elliptic:      n = K(k)*K'(d) / (K'(k)*K(d))
+// rounded up to the next integer.
+
+// ButterworthOrder returns the minimum order of a Butterworth filter meeting the specification
+//  Fs: sampling frequency
+//  fp: passband edge frequency
+//  fs: stopband edge frequency
+//  rp: maximum passband attenuation in decibels.
+//  rs: minimum stopband attenuation in decibels.
+// fc is the -3dB cutoff frequency to design the filter with (see NewButterworthLPN)
+// so that the passband specification is met exactly.
+func ButterworthOrder(Fs, fp, fs, rp, rs float64) (n int, fc float64, err error) {
+	k, d, err := selectivity(Fs, fp, fs, rp, rs)
+	if err != nil {
+		return 0, 0, err
+	}
+	n = ceilOrder(math.Log(1/d) / math.Log(1/k))
+	return n, butterworthCutoff(Fs, fp, rp, n, fp > fs), nil
+}
+
+// butterworthCutoff returns the -3dB cutoff frequency of a Butterworth filter of order n
+// with attenuation rp at the passband edge fp by solving |H(jWp)|^2 = 1/(1+e^2) for Wc.
+func butterworthCutoff(Fs, fp, rp float64, n int, highpass bool) float64 {
+	e := math.Sqrt(math.Pow(10, rp/10) - 1)
+	wp := math.Tan(math.Pi * fp / Fs)
+	wc := wp * math.Pow(e, -1/float64(n))
+	if highpass {
+		wc = wp * math.Pow(e, 1/float64(n))
+	}
+	return Fs / math.Pi * math.Atan(wc)
+}
+
+// Chebyshev1Order returns the minimum order of a Chebyshev Type I filter meeting
+// the specification. See ButterworthOrder for the meaning of the arguments.
+// The filter is designed with passband edge fp and ripple rp (see NewChebyshev1LP).
+func Chebyshev1Order(Fs, fp, fs, rp, rs float64) (n int, err error) {
+	k, d, err := selectivity(Fs, fp, fs, rp, rs)
+	if err != nil {
+		return 0, err
+	}
+	return ceilOrder(math.Acosh(1/d) / math.Acosh(1/k)), nil
+}
+
+// Chebyshev2Order returns the minimum order of a Chebyshev Type II filter meeting
+// the specification. See ButterworthOrder for the meaning of the arguments.
+// The filter is designed with stopband edge fs and attenuation rs (see NewChebyshev2LP).
+func Chebyshev2Order(Fs, fp, fs, rp, rs float64) (n int, err error) {
+	return Chebyshev1Order(Fs, fp, fs, rp, rs)
+}
+
+// EllipticOrder returns the minimum order of an elliptic filter meeting the specification.
+// See ButterworthOrder for the meaning of the arguments. The filter is designed
+// with passband edge fp, ripple rp and attenuation rs (see NewEllipticLP).
+func EllipticOrder(Fs, fp, fs, rp, rs float64) (n int, err error) {
+	k, d, err := selectivity(Fs, fp, fs, rp, rs)
+	if err != nil {
+		return 0, err
+	}
+	K, Kp := ellipk(k)
+	K1, K1p := ellipk(d)
+	return ceilOrder(K * K1p / (Kp * K1)), nil
+}
+
+// selectivity validates a low pass or high pass specification and returns
+// the selectivity factor k and discrimination factor d.
+func selectivity(Fs, fp, fs, rp, rs float64) (k, d float64, err error) {
+	switch {
+	case rp <= 0 || rs <= rp:
+		return 0, 0, ErrBadRipple
+	case fp <= 0 || fs <= 0 || Fs <= 0:
+		return 0, 0, ErrBadFreq
+	case fp >= Fs/2 || fs >= Fs/2:
+		return 0, 0, ErrAboveNyquist
+	case fp == fs:
+		return 0, 0, ErrNegBandwidth
+	}
+	wp, ws := math.Tan(math.Pi*fp/Fs), math.Tan(math.Pi*fs/Fs)
+	k = wp / ws
+	if fp > fs {
+		k = ws / wp
+	}
+	d = math.Sqrt((math.Pow(10, rp/10) - 1) / (math.Pow(10, rs/10) - 1))
+	return k, d, nil
+}
+
+// ceilOrder rounds the order up while tolerating round-off on exact integer orders.
+func ceilOrder(n float64) int {
+	return int(math.Max(1, math.Ceil(n-1e-9)))
+}
+
+// Family is a classical filter approximation used to design filters from a specification.
+type Family int
+
+const (
+	// FamilyButterworth has a maximally flat passband and a monotonic response.
+	FamilyButterworth Family = iota
+	// FamilyChebyshev1 has an equiripple passband and a monotonic stopband.
+	FamilyChebyshev1
+	// FamilyChebyshev2 has a monotonic passband and an equiripple stopband.
+	FamilyChebyshev2
+	// FamilyElliptic has an equiripple passband and stopband.
+	FamilyElliptic
+)
+
+// Spec is a low pass (Fpass < Fstop) or high pass (Fpass > Fstop) filter specification.
+type Spec struct {
+	// Fs is the sampling frequency.
+	Fs float64
+	// Fpass and Fstop are the passband and stopband edge frequencies.
+	Fpass, Fstop float64
+	// Rp is the maximum passband attenuation in decibels.
+	Rp float64
+	// Rs is the minimum stopband attenuation in decibels.
+	Rs float64
+}
+
+// Margins reports how well a filter meets a specification.
+type Margins struct {
+	// Order of the designed filter.
+	Order int
+	// Ripple is the maximum attenuation in the passband in decibels.
+	Ripple float64
+	// Attenuation is the minimum attenuation in the stopband in decibels.
+	Attenuation float64
+	// PassbandMargin is Rp - Ripple. It is negative if the passband specification is not met.
+	PassbandMargin float64
+	// StopbandMargin is Attenuation - Rs. It is negative if the stopband specification is not met.
+	StopbandMargin float64
+}
+
+// Design creates the minimum order filter of the given family that meets the specification
+// and reports the margins achieved. The margins are measured on the designed filter's
+// frequency response so round-off may yield margins slightly below zero (around 1e-9 dB)
+// on edges met exactly by design.
+func Design(family Family, spec Spec) (*SOS, Margins, error) {
+	var (
+		n   int
+		err error
+	)
+	switch family {
+	case FamilyButterworth:
+		n, _, err = ButterworthOrder(spec.Fs, spec.Fpass, spec.Fstop, spec.Rp, spec.Rs)
+	case FamilyChebyshev1:
+		n, err = Chebyshev1Order(spec.Fs, spec.Fpass, spec.Fstop, spec.Rp, spec.Rs)
+	case FamilyChebyshev2:
+		n, err = Chebyshev2Order(spec.Fs, spec.Fpass, spec.Fstop, spec.Rp, spec.Rs)
+	case FamilyElliptic:
+		n, err = EllipticOrder(spec.Fs, spec.Fpass, spec.Fstop, spec.Rp, spec.Rs)
+	default:
+		return nil, Margins{}, ErrBadFamily
+	}
+	if err != nil {
+		return nil, Margins{}, err
+	}
+	s, err := designOrder(family, spec, n)
+	if err != nil {
+		return nil, Margins{}, err
+	}
+	return s, spec.margins(s, n), nil
+}
+
+// designOrder designs a filter of the given family and order n from the specification.
+func designOrder(family Family, spec Spec, n int) (*SOS, error) {
+	lowpass := spec.Fpass < spec.Fstop
+	switch family {
+	case FamilyButterworth:
+		fc := butterworthCutoff(spec.Fs, spec.Fpass, spec.Rp, n, !lowpass)
+		if lowpass {
+			return NewButterworthLPN(spec.Fs, fc, n)
+		}
+		return NewButterworthHPN(spec.Fs, fc, n)
+	case FamilyChebyshev1:
+		if lowpass {
+			return NewChebyshev1LP(spec.Fs, spec.Fpass, spec.Rp, n)
+		}
+		return NewChebyshev1HP(spec.Fs, spec.Fpass, spec.Rp, n)
+	case FamilyChebyshev2:
+		if lowpass {
+			return NewChebyshev2LP(spec.Fs, spec.Fstop, spec.Rs, n)
+		}
+		return NewChebyshev2HP(spec.Fs, spec.Fstop, spec.Rs, n)
+	case FamilyElliptic:
+		if lowpass {
+			return NewEllipticLP(spec.Fs, spec.Fpass, spec.Rp, spec.Rs, n)
+		}
+		return NewEllipticHP(spec.Fs, spec.Fpass, spec.Rp, spec.Rs, n)
+	}
+	return nil, ErrBadFamily
+}
+
+// margins measures the passband and stopband attenuation of s on a dense frequency grid.
+func (spec Spec) margins(s *SOS, n int) Margins {
+	const points = 2048
+	H := s.getH()
+	att := func(f float64) float64 {
+		return -20 * math.Log10(cmplx.Abs(H(cmplx.Rect(1, 2*math.Pi*f/spec.Fs))))
+	}
+	// Passband and stopband intervals.
+	p0, p1 := 0., spec.Fpass
+	s0, s1 := spec.Fstop, spec.Fs/2
+	if spec.Fpass > spec.Fstop {
+		p0, p1 = spec.Fpass, spec.Fs/2
+		s0, s1 = 0, spec.Fstop
+	}
+	m := Margins{Order: n, Ripple: math.Inf(-1), Attenuation: math.Inf(1)}
+	for i := 0; i <= points; i++ {
+		fp := p0 + (p1-p0)*float64(i)/points
+		fs := s0 + (s1-s0)*float64(i)/points
+		m.Ripple = math.Max(m.Ripple, att(fp))
+		m.Attenuation = math.Min(m.Attenuation, att(fs))
+	}
+	m.PassbandMargin = spec.Rp - m.Ripple
+	m.StopbandMargin = m.Attenuation - spec.Rs
+	return m
+}
@@ -0,0 +1,62 @@
+package biquad
+
+import (
+	"testing"
+)
+
+func TestDesign(t *testing.T) {
+	const tol = 1e-6 // dB
+	specs := []Spec{
+		{Fs: 1e3, Fpass: 100, Fstop: 150, Rp: 1, Rs: 40},
+		{Fs: 1e3, Fpass: 100, Fstop: 120, Rp: 0.5, Rs: 60},
+		{Fs: 48e3, Fpass: 1000, Fstop: 4000, Rp: 3, Rs: 30},
+		{Fs: 1e3, Fpass: 150, Fstop: 100, Rp: 1, Rs: 40},
+		{Fs: 48e3, Fpass: 8000, Fstop: 6000, Rp: 0.1, Rs: 80},
+	}
+	families := []struct {
+		name   string
+		family Family
+	}{
+		{"Butterworth", FamilyButterworth},
+		{"Chebyshev1", FamilyChebyshev1},
+		{"Chebyshev2", FamilyChebyshev2},
+		{"Elliptic", FamilyElliptic},
+	}
+	for _, spec := range specs {
+		orders := make([]int, len(families))
+		for i, fam := range families {
+			s, m, err := Design(fam.family, spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			orders[i] = m.Order
+			if m.PassbandMargin < -tol || m.StopbandMargin < -tol {
+				t.Errorf("%s %+v: specification not met: %+v", fam.name, spec, m)
+			}
+			if s.Len() != (m.Order+1)/2 {
+				t.Errorf("%s: expected %d sections for order %d, got %d", fam.name, (m.Order+1)/2, m.Order, s.Len())
+			}
+			// The estimated order is minimal: one order less does not meet the specification.
+			if m.Order == 1 {
+				continue
+			}
+			s, err = designOrder(fam.family, spec, m.Order-1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if lower := spec.margins(s, m.Order-1); lower.PassbandMargin >= -tol && lower.StopbandMargin >= -tol {
+				t.Errorf("%s %+v: order %d is not minimal, order %d meets spec: %+v", fam.name, spec, m.Order, m.Order-1, lower)
+			}
+		}
+		t.Logf("%+v orders: %v", spec, orders)
+		if !(orders[3] <= orders[1] && orders[1] == orders[2] && orders[2] <= orders[0]) {
+			t.Errorf("expected elliptic <= chebyshev <= butterworth orders, got %v", orders)
+		}
+	}
+	if _, _, err := Design(FamilyElliptic, Spec{Fs: 1e3, Fpass: 100, Fstop: 100, Rp: 1, Rs: 40}); err != ErrNegBandwidth {
+		t.Errorf("expected ErrNegBandwidth, got %v", err)
+	}
+	if _, err := EllipticOrder(1e3, 100, 150, 40, 1); err != ErrBadRipple {
+		t.Errorf("expected ErrBadRipple, got %v", err)
+	}
+}
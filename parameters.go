@@ -36,3 +36,13 @@ func (a alphaCalc) s(w0, A, S float64) (alpha float64) {
 
 // A = sqrt(10^(DBgain/20))
 // 1/Q = 2 * sinh(ln2/2 * BW * w0 / sin(w0))
+
+// dbGainToA calculates the A parameter used by peaking and shelving filters.
+func dbGainToA(dBgain float64) float64 {
+	return math.Pow(10, dBgain/40)
+}
+
+// validSlope reports whether the shelf slope S yields a real alpha for a given A.
+func validSlope(A, S float64) bool {
+	return S > 0 && (A+1/A)*(1/S-1)+2 >= 0
+}
@@ -0,0 +1,80 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestQConstructors(t *testing.T) {
+	const (
+		fs = 1e3
+		f0 = 50.
+		BW = 1.
+	)
+	w0 := 2 * math.Pi * f0 / fs
+	// 1/Q = 2 * sinh(ln2/2 * BW * w0 / sin(w0))
+	Q := 1 / (2 * math.Sinh(math.Ln2/2*BW*w0/math.Sin(w0)))
+	type H interface {
+		getH() func(z complex128) complex128
+	}
+	pair := func(a H, errA error, b H, errB error) [2]H {
+		if errA != nil || errB != nil {
+			t.Fatal(errA, errB)
+		}
+		return [2]H{a, b}
+	}
+	lpBW, errLPBW := NewLowPass(fs, f0, BW)
+	lpQ, errLPQ := NewLowPassQ(fs, f0, Q)
+	hpBW, errHPBW := NewHighPass(fs, f0, BW)
+	hpQ, errHPQ := NewHighPassQ(fs, f0, Q)
+	bpBW, errBPBW := NewBandPass(fs, f0, BW)
+	bpQ, errBPQ := NewBandPassQ(fs, f0, Q)
+	nBW, errNBW := NewNotch(fs, f0, BW)
+	nQ, errNQ := NewNotchQ(fs, f0, Q)
+	for _, p := range [][2]H{
+		pair(lpBW, errLPBW, lpQ, errLPQ),
+		pair(hpBW, errHPBW, hpQ, errHPQ),
+		pair(bpBW, errBPBW, bpQ, errBPQ),
+		pair(nBW, errNBW, nQ, errNQ),
+	} {
+		for _, f := range []float64{1, 10, f0, 100, 400} {
+			z := cmplx.Rect(1, 2*math.Pi*f/fs)
+			if got, want := p[1].getH()(z), p[0].getH()(z); cmplx.Abs(got-want) > 1e-12 {
+				t.Errorf("%T at %gHz: Q constructor got %g, BW constructor got %g", p[0], f, got, want)
+			}
+		}
+	}
+
+	z0 := cmplx.Rect(1, w0)
+	skirt, err := NewBandPassSkirtQ(fs, f0, Q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cmplx.Abs(skirt.getH()(z0)); math.Abs(got-Q) > 1e-12 {
+		t.Errorf("constant skirt band pass: expected peak gain Q=%g, got %g", Q, got)
+	}
+	ap, err := NewAllPass(fs, f0, Q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []float64{1, 10, f0, 100, 400} {
+		if got := cmplx.Abs(ap.getH()(cmplx.Rect(1, 2*math.Pi*f/fs))); math.Abs(got-1) > 1e-12 {
+			t.Errorf("all pass at %gHz: expected unity gain, got %g", f, got)
+		}
+	}
+	for name, newQ := range map[string]func(Fs, f0, Q float64) (RecursiveFilter, error){
+		"low pass":  func(Fs, f0, Q float64) (RecursiveFilter, error) { return NewLowPassQ(Fs, f0, Q) },
+		"high pass": func(Fs, f0, Q float64) (RecursiveFilter, error) { return NewHighPassQ(Fs, f0, Q) },
+		"band pass": func(Fs, f0, Q float64) (RecursiveFilter, error) { return NewBandPassQ(Fs, f0, Q) },
+		"skirt":     func(Fs, f0, Q float64) (RecursiveFilter, error) { return NewBandPassSkirtQ(Fs, f0, Q) },
+		"notch":     func(Fs, f0, Q float64) (RecursiveFilter, error) { return NewNotchQ(Fs, f0, Q) },
+		"all pass":  func(Fs, f0, Q float64) (RecursiveFilter, error) { return NewAllPass(Fs, f0, Q) },
+	} {
+		for _, Q := range []float64{0, -1} {
+			if _, err := newQ(fs, f0, Q); err != ErrBadQ {
+				t.Errorf("%s: expected ErrBadQ for Q=%g, got %v", name, Q, err)
+			}
+		}
+	}
+}
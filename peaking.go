@@ -0,0 +1,85 @@
+package biquad
+
+import "math"
+
+// PeakingEQ is a peaking equalizer filter. It boosts or cuts
+// the signal around the working frequency and leaves the rest
+// of the spectrum at unity gain.
+type PeakingEQ struct {
+	blt
+}
+
+// NewPeakingEQ creates a peaking EQ filter from
+//  Fs: sampling frequency
+//  f0: center frequency
+//  BW: bandwidth of the filter in octaves between midpoint (dBgain/2) gain frequencies
+//  dBgain: gain at the center frequency in decibels. Negative values cut.
+func NewPeakingEQ(Fs, f0, BW, dBgain float64) (*PeakingEQ, error) {
+	switch {
+	case f0 >= Fs:
+		return nil, ErrBadWorkingFreq
+	case BW <= 0:
+		return nil, ErrNegBandwidth
+	case f0 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	}
+	w0 := 2 * math.Pi * (f0 / Fs)
+	alpha := alphaCalc{}.bw(w0, BW)
+	return newPeakingEQ(w0, alpha, dBgain), nil
+}
+
+// NewPeakingEQQ creates a peaking EQ filter from
+//  Fs: sampling frequency
+//  f0: center frequency
+//  Q: quality factor. A*Q is the classic EE quality factor (see parameters.go).
+//  dBgain: gain at the center frequency in decibels. Negative values cut.
+// A boost followed by a cut of the same gain, Q and f0 results in a flat unity gain response.
+func NewPeakingEQQ(Fs, f0, Q, dBgain float64) (*PeakingEQ, error) {
+	switch {
+	case f0 >= Fs:
+		return nil, ErrBadWorkingFreq
+	case Q <= 0:
+		return nil, ErrBadQ
+	case f0 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	}
+	w0 := 2 * math.Pi * (f0 / Fs)
+	alpha := alphaCalc{}.q(w0, Q)
+	return newPeakingEQ(w0, alpha, dBgain), nil
+}
+
+// NewPeakingEQSlope creates a peaking EQ filter from
+//  Fs: sampling frequency
+//  f0: center frequency
+//  S: slope parameter. S = 1 is the steepest slope that keeps gain monotonic on each side of f0.
+//  dBgain: gain at the center frequency in decibels. Negative values cut.
+func NewPeakingEQSlope(Fs, f0, S, dBgain float64) (*PeakingEQ, error) {
+	A := dbGainToA(dBgain)
+	switch {
+	case f0 >= Fs:
+		return nil, ErrBadWorkingFreq
+	case !validSlope(A, S):
+		return nil, ErrBadSlope
+	case f0 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	}
+	w0 := 2 * math.Pi * (f0 / Fs)
+	alpha := alphaCalc{}.s(w0, A, S)
+	return newPeakingEQ(w0, alpha, dBgain), nil
+}
+
+func newPeakingEQ(w0, alpha, dBgain float64) *PeakingEQ {
+	A := dbGainToA(dBgain)
+	cos := math.Cos(w0)
+	var (
+		b0 = 1 + alpha*A
+		b1 = -2 * cos
+		b2 = 1 - alpha*A
+		a0 = 1 + alpha/A
+		a1 = -2 * cos
+		a2 = 1 - alpha/A
+	)
+	return &PeakingEQ{
+		blt: newBLT(a0, a1, a2, b0, b1, b2),
+	}
+}
@@ -0,0 +1,53 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestPeakingEQGain(t *testing.T) {
+	const (
+		fs     = 48e3
+		f0     = 1e3
+		dBgain = 6.
+	)
+	w0 := 2 * math.Pi * f0 / fs
+	z0 := cmplx.Rect(1, w0)
+	boost, err := NewPeakingEQ(fs, f0, 1, dBgain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slope, err := NewPeakingEQSlope(fs, f0, 1, dBgain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []*PeakingEQ{boost, slope} {
+		got := 20 * math.Log10(cmplx.Abs(f.getH()(z0)))
+		if math.Abs(got-dBgain) > 1e-9 {
+			t.Errorf("expected %gdB at f0, got %gdB", dBgain, got)
+		}
+	}
+
+	// A boost followed by a cut with same Q and f0 should yield a wire.
+	up, err := NewPeakingEQQ(fs, f0, 2, dBgain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	down, err := NewPeakingEQQ(fs, f0, 2, -dBgain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []float64{10, 500, f0, 3e3, 20e3} {
+		z := cmplx.Rect(1, 2*math.Pi*f/fs)
+		if got := cmplx.Abs(up.getH()(z) * down.getH()(z)); math.Abs(got-1) > 1e-9 {
+			t.Errorf("boost+cut at %gHz: expected unity gain, got %g", f, got)
+		}
+	}
+	if _, err := NewPeakingEQSlope(fs, f0, 0, dBgain); err != ErrBadSlope {
+		t.Errorf("expected ErrBadSlope, got %v", err)
+	}
+	if _, err := NewPeakingEQQ(fs, f0, 0, dBgain); err != ErrBadQ {
+		t.Errorf("expected ErrBadQ for zero Q, got %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+package biquad
+
+import (
+	"image/color"
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/font"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// PoleZeroer is implemented by filters with known poles and zeros in the z-plane.
+// A digital filter is stable if all its poles lie inside the unit circle.
+type PoleZeroer interface {
+	Poles() []complex128
+	Zeros() []complex128
+}
+
+// Poles returns the poles of the biquad, the roots of
+//  z^2 + a_1*z + a_2
+// Poles at the origin matched by zeros at the origin are omitted.
+func (b *blt) Poles() []complex128 { return b.ZPK().P }
+
+// Zeros returns the finite zeros of the biquad, the roots of
+//  b_0*z^2 + b_1*z + b_2
+// Zeros at the origin matched by poles at the origin are omitted.
+func (b *blt) Zeros() []complex128 { return b.ZPK().Z }
+
+// IsStable reports whether all poles of the biquad lie strictly inside the unit circle.
+func (b *blt) IsStable() bool { return insideUnitCircle(b.Poles()) }
+
+// Poles returns the poles of the cascade of second order sections.
+func (s *SOS) Poles() []complex128 { return s.ZPK().P }
+
+// Zeros returns the finite zeros of the cascade of second order sections.
+func (s *SOS) Zeros() []complex128 { return s.ZPK().Z }
+
+// IsStable reports whether all poles of the cascade lie strictly inside the unit circle.
+func (s *SOS) IsStable() bool { return insideUnitCircle(s.Poles()) }
+
+// Poles returns the poles of the cascade, the poles of all its filters.
+// Filters which do not implement PoleZeroer are ignored.
+func (c *Cascade) Poles() []complex128 { return c.zpk().P }
+
+// Zeros returns the zeros of the cascade, the zeros of all its filters.
+// Filters which do not implement PoleZeroer are ignored.
+func (c *Cascade) Zeros() []complex128 { return c.zpk().Z }
+
+// IsStable reports whether all filters of the cascade are stable. The cascade
+// is reported unstable if a filter does not implement PoleZeroer since its
+// stability can not be verified.
+func (c *Cascade) IsStable() bool {
+	for _, f := range c.filters {
+		if _, ok := f.(PoleZeroer); !ok {
+			return false
+		}
+	}
+	return insideUnitCircle(c.Poles())
+}
+
+func (c *Cascade) zpk() ZPK {
+	var h ZPK
+	for _, f := range c.filters {
+		if pz, ok := f.(PoleZeroer); ok {
+			h.Z = append(h.Z, pz.Zeros()...)
+			h.P = append(h.P, pz.Poles()...)
+		}
+	}
+	return h.cancelOrigin()
+}
+
+// Poles returns the poles of the summed output of the bank, the poles of all its bands.
+// Poles shared between bands are repeated. Bands which do not implement
+// PoleZeroer are ignored.
+func (b *Bank) Poles() []complex128 {
+	var p []complex128
+	for _, f := range b.filters {
+		if pz, ok := f.(PoleZeroer); ok {
+			p = append(p, pz.Poles()...)
+		}
+	}
+	return p
+}
+
+// Zeros returns the zeros of the summed output of the bank. The weighted sum
+// of the band transfer functions is brought to a common denominator
+//  H(z) = SUM w_i*N_i(z)*PROD_{j!=i} D_j(z) / PROD D_j(z)
+// and the roots of the numerator are found numerically. Zeros may therefore
+// be less accurate than poles. The result is nil if a band does not expose
+// its zero-pole-gain representation.
+func (b *Bank) Zeros() []complex128 {
+	hs := make([]ZPK, len(b.filters))
+	for i, f := range b.filters {
+		z, ok := f.(interface{ ZPK() ZPK })
+		if !ok {
+			return nil
+		}
+		hs[i] = z.ZPK()
+	}
+	var num []complex128
+	for i := range hs {
+		term := polyFromRoots(hs[i].Z)
+		for k := range term {
+			term[k] *= complex(b.weights[i]*hs[i].K, 0)
+		}
+		for j := range hs {
+			if j != i {
+				term = polyMulComplex(term, polyFromRoots(hs[j].P))
+			}
+		}
+		num = polyAdd(num, term)
+	}
+	z := polyRoots(polyTrim(num))
+	forceConjugates(z)
+	return z
+}
+
+// IsStable reports whether all bands of the bank are stable. The bank
+// is reported unstable if a band does not implement PoleZeroer since its
+// stability can not be verified.
+func (b *Bank) IsStable() bool {
+	for _, f := range b.filters {
+		if _, ok := f.(PoleZeroer); !ok {
+			return false
+		}
+	}
+	return insideUnitCircle(b.Poles())
+}
+
+// PlotPoleZero returns a pole-zero map of a filter in the z-plane. Poles are
+// drawn as crosses and zeros as rings along with the unit circle. Save the
+// plot with equal width and height to preserve the aspect ratio.
+func PlotPoleZero(pz PoleZeroer) (*plot.Plot, error) {
+	poles, zeros := pz.Poles(), pz.Zeros()
+	p := plot.New()
+	p.Title.Text = "Pole-Zero Map"
+	p.X.Label.Text = "Real"
+	p.Y.Label.Text = "Imaginary"
+	circle := make(plotter.XYs, 257)
+	for i := range circle {
+		circle[i].X, circle[i].Y = math.Cos(2*math.Pi*float64(i)/256), math.Sin(2*math.Pi*float64(i)/256)
+	}
+	unit, err := plotter.NewLine(circle)
+	if err != nil {
+		return nil, err
+	}
+	unit.Color = color.Gray{Y: 128}
+	unit.Dashes = []font.Length{font.Length(2), font.Length(2)}
+	p.Add(plotter.NewGrid(), unit)
+	r := 1.2
+	for i, roots := range [][]complex128{poles, zeros} {
+		if len(roots) == 0 {
+			continue
+		}
+		xy := make(plotter.XYs, len(roots))
+		for j, v := range roots {
+			xy[j].X, xy[j].Y = real(v), imag(v)
+			r = math.Max(r, 1.1*cmplx.Abs(v))
+		}
+		sc, err := plotter.NewScatter(xy)
+		if err != nil {
+			return nil, err
+		}
+		sc.GlyphStyle.Radius = font.Length(4)
+		if i == 0 {
+			sc.GlyphStyle.Shape = draw.CrossGlyph{}
+			sc.GlyphStyle.Color = color.RGBA{R: 200, A: 255}
+			p.Legend.Add("poles", sc)
+		} else {
+			sc.GlyphStyle.Shape = draw.RingGlyph{}
+			sc.GlyphStyle.Color = color.RGBA{B: 200, A: 255}
+			p.Legend.Add("zeros", sc)
+		}
+		p.Add(sc)
+	}
+	p.X.Min, p.X.Max = -r, r
+	p.Y.Min, p.Y.Max = -r, r
+	return p, nil
+}
+
+// insideUnitCircle reports whether all roots have magnitude less than one.
+func insideUnitCircle(roots []complex128) bool {
+	for _, r := range roots {
+		if !(cmplx.Abs(r) < 1) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/plot/font"
+)
+
+var (
+	_ PoleZeroer = (*LowPass)(nil)
+	_ PoleZeroer = (*SOS)(nil)
+	_ PoleZeroer = (*Cascade)(nil)
+	_ PoleZeroer = (*Bank)(nil)
+)
+
+func TestPoleZero(t *testing.T) {
+	const fs = 1e3
+	lp, err := NewLowPass(fs, 100, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lp.IsStable() || len(lp.Poles()) != 2 || len(lp.Zeros()) != 2 {
+		t.Errorf("expected stable low pass with two poles and zeros, got poles %v and zeros %v", lp.Poles(), lp.Zeros())
+	}
+	for _, z := range lp.Zeros() {
+		if cmplx.Abs(z+1) > 1e-6 {
+			t.Errorf("expected low pass zeros at z=-1, got %v", z)
+		}
+	}
+	// (z - 1.1)^2 has a double pole outside the unit circle.
+	unstable, err := NewBiquad(1, 0, 0, 1, -2.2, 1.21)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unstable.IsStable() {
+		t.Errorf("expected unstable biquad with poles %v", unstable.Poles())
+	}
+	// Poles on the unit circle are not stable.
+	marginal, _ := NewBiquad(1, 0, 0, 1, 0, 1)
+	if marginal.IsStable() {
+		t.Errorf("expected marginally stable biquad to be reported unstable, poles %v", marginal.Poles())
+	}
+
+	bw, err := NewButterworthLPN(fs, 100, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, z := bw.Poles(), bw.Zeros(); len(p) != 5 || len(z) != 5 || !bw.IsStable() {
+		t.Errorf("expected stable 5th order filter with 5 poles and zeros, got poles %v and zeros %v", p, z)
+	}
+	if c := NewCascade(bw, lp); !c.IsStable() || len(c.Poles()) != 7 {
+		t.Errorf("expected stable cascade with 7 poles, got %v", c.Poles())
+	}
+	if c := NewCascade(bw, unstable); c.IsStable() {
+		t.Error("expected cascade with unstable filter to be unstable")
+	}
+	if c := NewCascade(bw, &opaqueFilter{}); c.IsStable() {
+		t.Error("expected cascade with unknown filter to be reported unstable")
+	}
+
+	// A band with zero weight contributes its poles as zeros of the sum.
+	b := NewBank(bw, lp)
+	b.SetWeight(1, 0)
+	want := append(bw.Zeros(), lp.Poles()...)
+	got := b.Zeros()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d bank zeros, got %v", len(want), got)
+	}
+	// Five fold zero at z=-1 is ill conditioned: roots are only accurate to ~eps^(1/5).
+	byReal := func(r []complex128) {
+		sort.Slice(r, func(i, j int) bool { return real(r[i]) < real(r[j]) })
+	}
+	byReal(got)
+	byReal(want)
+	for i := range got {
+		if cmplx.Abs(got[i]-want[i]) > 1e-2 {
+			t.Errorf("expected bank zero %v, got %v", want[i], got[i])
+		}
+	}
+	if !b.IsStable() || len(b.Poles()) != 7 {
+		t.Errorf("expected stable bank with 7 poles, got %v", b.Poles())
+	}
+
+	p, err := PlotPoleZero(bw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.X.Max < 1 || math.Abs(p.X.Max-p.Y.Max) > 0 {
+		t.Errorf("expected square axes containing the unit circle, got x<%g y<%g", p.X.Max, p.Y.Max)
+	}
+	if err := p.Save(15*font.Centimeter, 15*font.Centimeter, filepath.Join(t.TempDir(), "pz.png")); err != nil {
+		t.Fatal(err)
+	}
+}
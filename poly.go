@@ -0,0 +1,124 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Polynomials are stored with coefficients in descending powers:
+//  p(x) = c[0]*x^n + c[1]*x^(n-1) + ... + c[n]
+
+// polyRoots returns the roots of the polynomial with coefficients c
+// using the Aberth-Ehrlich simultaneous iteration. Leading zero
+// coefficients are ignored and trailing zero coefficients yield roots at the origin.
+func polyRoots(c []complex128) []complex128 {
+	for len(c) > 0 && c[0] == 0 {
+		c = c[1:]
+	}
+	var roots []complex128
+	for len(c) > 0 && c[len(c)-1] == 0 {
+		roots = append(roots, 0)
+		c = c[:len(c)-1]
+	}
+	n := len(c) - 1
+	if n < 1 {
+		return roots
+	}
+	// Normalize to a monic polynomial.
+	monic := make([]complex128, len(c))
+	for i := range c {
+		monic[i] = c[i] / c[0]
+	}
+	// Initial guesses on a circle with radius equal to the geometric mean of the
+	// root magnitudes, rotated to avoid symmetric starting configurations.
+	r := math.Pow(cmplx.Abs(monic[n]), 1/float64(n))
+	z := make([]complex128, n)
+	for i := range z {
+		z[i] = cmplx.Rect(r, 2*math.Pi*float64(i)/float64(n)+0.4)
+	}
+	const maxIter = 500
+	for iter := 0; iter < maxIter; iter++ {
+		converged := true
+		for i := range z {
+			p, dp := polyEvalDeriv(monic, z[i])
+			if p == 0 {
+				continue
+			}
+			ratio := p / dp
+			var sum complex128
+			for j := range z {
+				if j != i {
+					sum += 1 / (z[i] - z[j])
+				}
+			}
+			w := ratio / (1 - ratio*sum)
+			z[i] -= w
+			if cmplx.Abs(w) > 1e-15*math.Max(1, cmplx.Abs(z[i])) {
+				converged = false
+			}
+		}
+		if converged {
+			break
+		}
+	}
+	return append(roots, z...)
+}
+
+// polyEvalDeriv evaluates the polynomial c and its derivative at x.
+func polyEvalDeriv(c []complex128, x complex128) (p, dp complex128) {
+	for i := range c {
+		dp = dp*x + p
+		p = p*x + c[i]
+	}
+	return p, dp
+}
+
+// polyFromRoots returns the coefficients of the monic polynomial with the given roots.
+func polyFromRoots(roots []complex128) []complex128 {
+	c := make([]complex128, 1, len(roots)+1)
+	c[0] = 1
+	for _, r := range roots {
+		c = append(c, 0)
+		for i := len(c) - 1; i > 0; i-- {
+			c[i] -= r * c[i-1]
+		}
+	}
+	return c
+}
+
+// polyMulComplex returns the product of polynomials p and q.
+func polyMulComplex(p, q []complex128) []complex128 {
+	r := make([]complex128, len(p)+len(q)-1)
+	for i := range p {
+		for j := range q {
+			r[i+j] += p[i] * q[j]
+		}
+	}
+	return r
+}
+
+// polyAdd returns the sum of polynomials p and q.
+func polyAdd(p, q []complex128) []complex128 {
+	if len(p) < len(q) {
+		p, q = q, p
+	}
+	r := append([]complex128{}, p...)
+	off := len(p) - len(q)
+	for i := range q {
+		r[off+i] += q[i]
+	}
+	return r
+}
+
+// polyTrim discards leading coefficients which are negligible compared to
+// the largest coefficient, as left over by cancellation.
+func polyTrim(c []complex128) []complex128 {
+	var max float64
+	for i := range c {
+		max = math.Max(max, cmplx.Abs(c[i]))
+	}
+	for len(c) > 0 && cmplx.Abs(c[0]) <= 1e-12*max {
+		c = c[1:]
+	}
+	return c
+}
@@ -0,0 +1,196 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Responder is implemented by filters with a known frequency response.
+// All filters in this package implement Responder.
+type Responder interface {
+	// Response returns the complex frequency response H(e^{j*2*pi*f/Fs})
+	// at frequencies f (in Hz) for a sampling frequency Fs.
+	Response(Fs float64, f []float64) []complex128
+}
+
+// Spacing selects how frequencies are distributed by FreqResponse.
+type Spacing int
+
+const (
+	// LinearSpacing distributes frequencies evenly from DC to the Nyquist frequency.
+	LinearSpacing Spacing = iota
+	// LogSpacing distributes frequencies logarithmically over the three decades
+	// below the Nyquist frequency.
+	LogSpacing
+)
+
+// FreqResponse evaluates the frequency response of a filter at n frequencies
+// up to and including the Nyquist frequency Fs/2 and returns the frequencies
+// (in Hz) together with the response. A negative n is treated as zero and yields empty slices.
+func FreqResponse(r Responder, Fs float64, n int, spacing Spacing) (f []float64, h []complex128) {
+	if n < 0 {
+		n = 0
+	}
+	f = make([]float64, n)
+	nyq := Fs / 2
+	for i := range f {
+		t := 1.
+		if n > 1 {
+			t = float64(i) / float64(n-1)
+		}
+		switch spacing {
+		case LogSpacing:
+			f[i] = nyq * math.Pow(10, 3*(t-1))
+		default:
+			f[i] = nyq * t
+		}
+	}
+	return f, r.Response(Fs, f)
+}
+
+// MagnitudeDB returns the magnitude of a complex frequency response in decibels.
+func MagnitudeDB(h []complex128) []float64 {
+	db := make([]float64, len(h))
+	for i := range h {
+		db[i] = 20 * math.Log10(cmplx.Abs(h[i]))
+	}
+	return db
+}
+
+// Phase returns the unwrapped phase in radians of a complex frequency response.
+// Jumps between consecutive values greater than pi are removed by adding multiples of 2*pi.
+func Phase(h []complex128) []float64 {
+	ph := make([]float64, len(h))
+	var offset float64
+	for i := range h {
+		ph[i] = cmplx.Phase(h[i])
+		if i > 0 {
+			d := ph[i] + offset - ph[i-1]
+			offset -= 2 * math.Pi * math.Round(d/(2*math.Pi))
+		}
+		ph[i] += offset
+	}
+	return ph
+}
+
+// Response returns the complex frequency response of the biquad at frequencies
+// f (in Hz) for a sampling frequency Fs.
+func (b *blt) Response(Fs float64, f []float64) []complex128 {
+	return response(b.getH(), Fs, f)
+}
+
+// MagnitudeDB returns the gain of the biquad in decibels at frequencies
+// f (in Hz) for a sampling frequency Fs.
+func (b *blt) MagnitudeDB(Fs float64, f []float64) []float64 {
+	return MagnitudeDB(b.Response(Fs, f))
+}
+
+// Phase returns the unwrapped phase response of the biquad in radians at
+// frequencies f (in Hz) for a sampling frequency Fs.
+func (b *blt) Phase(Fs float64, f []float64) []float64 {
+	return Phase(b.Response(Fs, f))
+}
+
+// Response returns the complex frequency response of the cascade at frequencies
+// f (in Hz) for a sampling frequency Fs.
+func (s *SOS) Response(Fs float64, f []float64) []complex128 {
+	return response(s.getH(), Fs, f)
+}
+
+// MagnitudeDB returns the gain of the cascade in decibels at frequencies
+// f (in Hz) for a sampling frequency Fs.
+func (s *SOS) MagnitudeDB(Fs float64, f []float64) []float64 {
+	return MagnitudeDB(s.Response(Fs, f))
+}
+
+// Phase returns the unwrapped phase response of the cascade in radians at
+// frequencies f (in Hz) for a sampling frequency Fs.
+func (s *SOS) Phase(Fs float64, f []float64) []float64 {
+	return Phase(s.Response(Fs, f))
+}
+
+// Response returns the complex frequency response of the cascade at frequencies
+// f (in Hz) for a sampling frequency Fs. The response is NaN if a filter of the
+// cascade does not implement Responder.
+func (c *Cascade) Response(Fs float64, f []float64) []complex128 {
+	return response(c.getH(), Fs, f)
+}
+
+// MagnitudeDB returns the gain of the cascade in decibels at frequencies
+// f (in Hz) for a sampling frequency Fs.
+func (c *Cascade) MagnitudeDB(Fs float64, f []float64) []float64 {
+	return MagnitudeDB(c.Response(Fs, f))
+}
+
+// Phase returns the unwrapped phase response of the cascade in radians at
+// frequencies f (in Hz) for a sampling frequency Fs.
+func (c *Cascade) Phase(Fs float64, f []float64) []float64 {
+	return Phase(c.Response(Fs, f))
+}
+
+// GroupDelay returns the group delay of the cascade in seconds at
+// frequencies f (in Hz) for a sampling frequency Fs. It is the sum of
+// the group delays of the filters in the cascade.
+func (c *Cascade) GroupDelay(Fs float64, f []float64) []float64 {
+	gd := make([]float64, len(f))
+	for _, filter := range c.filters {
+		g, ok := filter.(interface {
+			GroupDelay(Fs float64, f []float64) []float64
+		})
+		if !ok {
+			return groupDelay(c.getH(), Fs, f)
+		}
+		for i, v := range g.GroupDelay(Fs, f) {
+			gd[i] += v
+		}
+	}
+	return gd
+}
+
+// Response returns the complex frequency response of the summed output of the bank
+// at frequencies f (in Hz) for a sampling frequency Fs. The response is NaN if
+// a band does not implement Responder.
+func (b *Bank) Response(Fs float64, f []float64) []complex128 {
+	return response(b.getH(), Fs, f)
+}
+
+// MagnitudeDB returns the gain of the summed output of the bank in decibels
+// at frequencies f (in Hz) for a sampling frequency Fs.
+func (b *Bank) MagnitudeDB(Fs float64, f []float64) []float64 {
+	return MagnitudeDB(b.Response(Fs, f))
+}
+
+// Phase returns the unwrapped phase response of the summed output of the bank
+// in radians at frequencies f (in Hz) for a sampling frequency Fs.
+func (b *Bank) Phase(Fs float64, f []float64) []float64 {
+	return Phase(b.Response(Fs, f))
+}
+
+// GroupDelay returns the group delay of the summed output of the bank in seconds
+// at frequencies f (in Hz) for a sampling frequency Fs. It is calculated numerically
+// from the phase response.
+func (b *Bank) GroupDelay(Fs float64, f []float64) []float64 {
+	return groupDelay(b.getH(), Fs, f)
+}
+
+// response evaluates H on the unit circle at frequencies f (in Hz).
+func response(H func(z complex128) complex128, Fs float64, f []float64) []complex128 {
+	h := make([]complex128, len(f))
+	for i := range f {
+		h[i] = H(cmplx.Rect(1, 2*math.Pi*f[i]/Fs))
+	}
+	return h
+}
+
+// groupDelay approximates the group delay in seconds with a central difference of the phase
+//  tau(w) = -(arg(H(w+dw)) - arg(H(w-dw))) / (2*dw)
+func groupDelay(H func(z complex128) complex128, Fs float64, f []float64) []float64 {
+	const dw = 1e-6 // rad/sample
+	gd := make([]float64, len(f))
+	for i := range f {
+		w := 2 * math.Pi * f[i] / Fs
+		ratio := H(cmplx.Rect(1, w+dw)) / H(cmplx.Rect(1, w-dw))
+		gd[i] = -cmplx.Phase(ratio) / (2 * dw) / Fs
+	}
+	return gd
+}
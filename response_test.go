@@ -0,0 +1,124 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+var (
+	_ Responder = (*LowPass)(nil)
+	_ Responder = (*Biquad)(nil)
+	_ Responder = (*SOS)(nil)
+	_ Responder = (*Cascade)(nil)
+	_ Responder = (*Bank)(nil)
+)
+
+func TestResponse(t *testing.T) {
+	const (
+		fs  = 1e3
+		fc  = 100.
+		n   = 4
+		tol = 1e-9
+	)
+	lp, err := NewButterworthLPN(fs, fc, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := lp.MagnitudeDB(fs, []float64{0, fc})
+	if math.Abs(db[0]) > tol || math.Abs(db[1]+3.0102999566398125) > tol {
+		t.Errorf("expected 0dB at DC and -3dB at cutoff, got %v", db)
+	}
+
+	// Unwrapped phase decreases monotonically towards -n*pi/2 at the Nyquist frequency.
+	f, h := FreqResponse(lp, fs, 1000, LinearSpacing)
+	if f[0] != 0 || f[len(f)-1] != fs/2 || len(h) != len(f) {
+		t.Fatalf("bad linear frequency grid: [%g, %g]", f[0], f[len(f)-1])
+	}
+	ph := Phase(h[:len(h)-1]) // Response is zero at Nyquist.
+	for i := 1; i < len(ph); i++ {
+		if ph[i] > ph[i-1] {
+			t.Fatalf("expected monotonically decreasing phase, got %g after %g at %gHz", ph[i], ph[i-1], f[i])
+		}
+	}
+	if got := ph[len(ph)-1]; math.Abs(got+n*math.Pi/2) > 0.01 {
+		t.Errorf("expected unwrapped phase near %g at Nyquist, got %g", -n*math.Pi/2, got)
+	}
+	if got := lp.Phase(fs, f[:len(f)-1]); got[len(got)-1] != ph[len(ph)-1] {
+		t.Errorf("expected Phase method to match Phase of Response, got %g and %g", got[len(got)-1], ph[len(ph)-1])
+	}
+
+	if f, h := FreqResponse(lp, fs, -1, LinearSpacing); len(f) != 0 || len(h) != 0 {
+		t.Errorf("expected empty response for negative n, got %d frequencies", len(f))
+	}
+	f, _ = FreqResponse(lp, fs, 31, LogSpacing)
+	if math.Abs(f[0]-fs/2000) > tol || f[len(f)-1] != fs/2 {
+		t.Errorf("bad log frequency grid: [%g, %g]", f[0], f[len(f)-1])
+	}
+	for i := 2; i < len(f); i++ {
+		if r0, r1 := f[i-1]/f[i-2], f[i]/f[i-1]; math.Abs(r0-r1) > tol {
+			t.Fatalf("expected constant ratio between log spaced frequencies, got %g and %g", r0, r1)
+		}
+	}
+
+	// Group delay of cascades adds up. Banks compute it numerically.
+	ap, err := NewAllPass(fs, 200, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewCascade(lp, ap)
+	b := NewBank(lp)
+	f = []float64{1, 50, 100, 150, 300}
+	want, wantAP := lp.GroupDelay(fs, f), ap.GroupDelay(fs, f)
+	gotC, gotB := c.GroupDelay(fs, f), b.GroupDelay(fs, f)
+	for i := range f {
+		if math.Abs(gotC[i]-want[i]-wantAP[i]) > tol {
+			t.Errorf("cascade at %gHz: expected group delay %g, got %g", f[i], want[i]+wantAP[i], gotC[i])
+		}
+		if math.Abs(gotB[i]-want[i]) > 1e-6*want[i] {
+			t.Errorf("bank at %gHz: expected group delay %g, got %g", f[i], want[i], gotB[i])
+		}
+	}
+	cdb, bdb := c.MagnitudeDB(fs, f), b.MagnitudeDB(fs, f)
+	for i, v := range lp.MagnitudeDB(fs, f) {
+		if math.Abs(cdb[i]-v) > tol || math.Abs(bdb[i]-v) > tol {
+			t.Errorf("at %gHz: expected %gdB for all-pass cascade and bank, got %g and %g", f[i], v, cdb[i], bdb[i])
+		}
+	}
+}
+
+func TestResponderChildren(t *testing.T) {
+	const fs = 1e3
+	lp, _ := NewLowPass(fs, 100, 1)
+	hp, _ := NewHighPass(fs, 200, 1)
+	freqs := []float64{0, 10, 100, 250, 499}
+	want := NewCascade(lp, hp).Response(fs, freqs)
+	// A filter outside the package only exposes its response through Responder.
+	got := NewCascade(responderOnly{lp}, responderOnly{hp}).Response(fs, freqs)
+	for i := range freqs {
+		if cmplx.Abs(got[i]-want[i]) > 1e-12 {
+			t.Errorf("cascade at %gHz: expected %v, got %v", freqs[i], want[i], got[i])
+		}
+	}
+	want = NewBank(lp, hp).Response(fs, freqs)
+	got = NewBank(responderOnly{lp}, responderOnly{hp}).Response(fs, freqs)
+	for i := range freqs {
+		if cmplx.Abs(got[i]-want[i]) > 1e-12 {
+			t.Errorf("bank at %gHz: expected %v, got %v", freqs[i], want[i], got[i])
+		}
+	}
+}
+
+// responderOnly hides the transfer function of a filter behind the Responder interface.
+type responderOnly struct {
+	f interface {
+		RecursiveFilter
+		Responder
+	}
+}
+
+func (r responderOnly) DiscreteProcess(x float64) { r.f.DiscreteProcess(x) }
+func (r responderOnly) YNext() float64            { return r.f.YNext() }
+func (r responderOnly) Response(Fs float64, f []float64) []complex128 {
+	return r.f.Response(Fs, f)
+}
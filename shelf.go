@@ -0,0 +1,73 @@
+package biquad
+
+import "math"
+
+// Shelf is a shelving filter. May represent low shelf
+// and high shelf filter implementations.
+type Shelf struct {
+	blt
+}
+
+// NewLowShelf creates a low shelf filter from
+//  Fs: sampling frequency
+//  f0: midpoint frequency of the shelf (where gain is dBgain/2)
+//  dBgain: gain of the shelf below f0 in decibels. Negative values cut.
+//  S: shelf slope. S = 1 is the steepest slope that keeps gain monotonic with frequency.
+func NewLowShelf(Fs, f0, dBgain, S float64) (*Shelf, error) {
+	A := dbGainToA(dBgain)
+	switch {
+	case f0 >= Fs:
+		return nil, ErrBadWorkingFreq
+	case !validSlope(A, S):
+		return nil, ErrBadSlope
+	case f0 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	}
+	w0 := 2 * math.Pi * (f0 / Fs)
+	cos := math.Cos(w0)
+	alpha := alphaCalc{}.s(w0, A, S)
+	sqA2alpha := 2 * math.Sqrt(A) * alpha
+	var (
+		b0 = A * ((A + 1) - (A-1)*cos + sqA2alpha)
+		b1 = 2 * A * ((A - 1) - (A+1)*cos)
+		b2 = A * ((A + 1) - (A-1)*cos - sqA2alpha)
+		a0 = (A + 1) + (A-1)*cos + sqA2alpha
+		a1 = -2 * ((A - 1) + (A+1)*cos)
+		a2 = (A + 1) + (A-1)*cos - sqA2alpha
+	)
+	return &Shelf{
+		blt: newBLT(a0, a1, a2, b0, b1, b2),
+	}, nil
+}
+
+// NewHighShelf creates a high shelf filter from
+//  Fs: sampling frequency
+//  f0: midpoint frequency of the shelf (where gain is dBgain/2)
+//  dBgain: gain of the shelf above f0 in decibels. Negative values cut.
+//  S: shelf slope. S = 1 is the steepest slope that keeps gain monotonic with frequency.
+func NewHighShelf(Fs, f0, dBgain, S float64) (*Shelf, error) {
+	A := dbGainToA(dBgain)
+	switch {
+	case f0 >= Fs:
+		return nil, ErrBadWorkingFreq
+	case !validSlope(A, S):
+		return nil, ErrBadSlope
+	case f0 <= 0 || Fs <= 0:
+		return nil, ErrBadFreq
+	}
+	w0 := 2 * math.Pi * (f0 / Fs)
+	cos := math.Cos(w0)
+	alpha := alphaCalc{}.s(w0, A, S)
+	sqA2alpha := 2 * math.Sqrt(A) * alpha
+	var (
+		b0 = A * ((A + 1) + (A-1)*cos + sqA2alpha)
+		b1 = -2 * A * ((A - 1) + (A+1)*cos)
+		b2 = A * ((A + 1) + (A-1)*cos - sqA2alpha)
+		a0 = (A + 1) - (A-1)*cos + sqA2alpha
+		a1 = 2 * ((A - 1) - (A+1)*cos)
+		a2 = (A + 1) - (A-1)*cos - sqA2alpha
+	)
+	return &Shelf{
+		blt: newBLT(a0, a1, a2, b0, b1, b2),
+	}, nil
+}
@@ -0,0 +1,46 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestShelfGain(t *testing.T) {
+	const (
+		fs     = 48e3
+		f0     = 1e3
+		dBgain = -9.
+	)
+	low, err := NewLowShelf(fs, f0, dBgain, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	high, err := NewHighShelf(fs, f0, dBgain, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dB := func(f *Shelf, z complex128) float64 { return 20 * math.Log10(cmplx.Abs(f.getH()(z))) }
+	dc, nyquist, mid := complex(1, 0), complex(-1, 0), cmplx.Rect(1, 2*math.Pi*f0/fs)
+	for _, test := range []struct {
+		name        string
+		f           *Shelf
+		dc, ny, mid float64
+	}{
+		{"low", low, dBgain, 0, dBgain / 2},
+		{"high", high, 0, dBgain, dBgain / 2},
+	} {
+		if got := dB(test.f, dc); math.Abs(got-test.dc) > 1e-9 {
+			t.Errorf("%s shelf: expected %gdB at DC, got %g", test.name, test.dc, got)
+		}
+		if got := dB(test.f, nyquist); math.Abs(got-test.ny) > 1e-9 {
+			t.Errorf("%s shelf: expected %gdB at Nyquist, got %g", test.name, test.ny, got)
+		}
+		if got := dB(test.f, mid); math.Abs(got-test.mid) > 1e-9 {
+			t.Errorf("%s shelf: expected %gdB at f0, got %g", test.name, test.mid, got)
+		}
+	}
+	if _, err := NewLowShelf(fs, f0, 20, 10); err != ErrBadSlope {
+		t.Errorf("expected ErrBadSlope, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package biquad
+
+// SOS is a cascade of second order sections (biquads). It represents
+// filters of order greater than two. The signal flows through each section
+// in order, the output of one section being the input of the next.
+type SOS struct {
+	sections []blt
+	// Initial conditions used by Filter.
+	initMode InitMode
+}
+
+// Filter applies the cascade of sections to a digital signal and returns
+// the filtered result. The length of the data must be greater than 2.
+// The filter state is first initialized according to the mode set with SetInit.
+func (s *SOS) Filter(signal Signal) (Signal, error) {
+	N := signal.Len()
+	if N < 3 {
+		return nil, ErrShortXY
+	}
+	fval := make([]float64, N)
+	s.init(signal)
+	for i := 0; i < N; i++ {
+		_, x := signal.XY(i)
+		s.advance(x)
+		fval[i] = s.ynext()
+	}
+	return filtered{
+		Signal: signal,
+		fval:   fval,
+	}, nil
+}
+
+// DiscreteProcess takes in the next signal data point
+// and processes it. DiscreteProcess expects data points
+// to be evenly spaced out in time.
+func (s *SOS) DiscreteProcess(x float64) {
+	s.advance(x)
+}
+
+// YNext returns the last result of the filter given by
+// DiscreteProcess.
+func (s *SOS) YNext() (y float64) {
+	return s.ynext()
+}
+
+// GroupDelay returns the group delay of the filter in seconds at
+// frequencies f (in Hz) for a sampling frequency Fs.
+func (s *SOS) GroupDelay(Fs float64, f []float64) []float64 {
+	gd := make([]float64, len(f))
+	for i := range s.sections {
+		for j, sgd := range s.sections[i].GroupDelay(Fs, f) {
+			gd[j] += sgd
+		}
+	}
+	return gd
+}
+
+// Len returns the number of second order sections in the cascade.
+func (s *SOS) Len() int { return len(s.sections) }
+
+func (s *SOS) advance(x float64) {
+	for i := range s.sections {
+		s.sections[i].advance(x)
+		x = s.sections[i].ynext()
+	}
+}
+
+func (s *SOS) ynext() float64 {
+	if len(s.sections) == 0 {
+		return 0
+	}
+	return s.sections[len(s.sections)-1].ynext()
+}
+
+func (s SOS) getH() func(z complex128) complex128 {
+	hs := make([]func(complex128) complex128, len(s.sections))
+	for i := range s.sections {
+		hs[i] = s.sections[i].getH()
+	}
+	return func(z complex128) complex128 {
+		h := complex(1, 0)
+		for i := range hs {
+			h *= hs[i](z)
+		}
+		return h
+	}
+}
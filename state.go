@@ -0,0 +1,142 @@
+package biquad
+
+// Stateful is implemented by filters whose internal state may be cleared,
+// saved and restored. All filters in this package implement Stateful.
+type Stateful interface {
+	// Reset clears the filter state as if no samples had been processed.
+	Reset()
+	// State returns a snapshot of the filter state.
+	State() State
+	// SetState restores a snapshot of the filter state.
+	SetState(State) error
+}
+
+// State is a snapshot of the internal state of a filter, the input and output
+// history of each of its second order sections. Composite filters such as
+// Cascade and Bank store the state of each of their filters in Filters.
+// State may be serialized, i.e. with encoding/json, to checkpoint a filter
+// and resume processing after a restart without a transient.
+type State struct {
+	// X holds the past inputs x[n-1] and x[n-2] of each section.
+	X [][2]float64 `json:"x,omitempty"`
+	// Y holds the past outputs y[n-1] and y[n-2] of each section.
+	Y [][2]float64 `json:"y,omitempty"`
+	// Filters holds the state of each filter of a composite filter.
+	Filters []State `json:"filters,omitempty"`
+}
+
+// Reset clears the state of the biquad as if no samples had been processed.
+func (b *blt) Reset() {
+	b.x, b.y = [3]float64{}, [3]float64{}
+}
+
+// State returns a snapshot of the input and output history of the biquad.
+func (b *blt) State() State {
+	x, y := b.history()
+	return State{X: [][2]float64{x}, Y: [][2]float64{y}}
+}
+
+// SetState restores the input and output history of the biquad.
+// The state must hold the history of exactly one section.
+func (b *blt) SetState(s State) error {
+	if len(s.X) != 1 || len(s.Y) != 1 {
+		return ErrStateLength
+	}
+	b.setHistory(s.X[0], s.Y[0])
+	return nil
+}
+
+// history returns past inputs x[n-1], x[n-2] and past outputs y[n-1], y[n-2].
+func (b *blt) history() (x, y [2]float64) {
+	for k := uint(1); k <= 2; k++ {
+		i := (b.ptr - k) % 3
+		x[k-1], y[k-1] = b.x[i], b.y[i]
+	}
+	return x, y
+}
+
+// Reset clears the state of all sections as if no samples had been processed.
+func (s *SOS) Reset() {
+	for i := range s.sections {
+		s.sections[i].Reset()
+	}
+}
+
+// State returns a snapshot of the input and output history of all sections.
+func (s *SOS) State() State {
+	var st State
+	for i := range s.sections {
+		x, y := s.sections[i].history()
+		st.X = append(st.X, x)
+		st.Y = append(st.Y, y)
+	}
+	return st
+}
+
+// SetState restores the input and output history of all sections.
+// The state must hold the history of as many sections as the cascade.
+func (s *SOS) SetState(st State) error {
+	if len(st.X) != len(s.sections) || len(st.Y) != len(s.sections) {
+		return ErrStateLength
+	}
+	for i := range s.sections {
+		s.sections[i].setHistory(st.X[i], st.Y[i])
+	}
+	return nil
+}
+
+// Reset clears the state of all filters of the cascade. Filters which
+// do not implement Stateful are left untouched.
+func (c *Cascade) Reset() { resetAll(c.filters) }
+
+// State returns a snapshot of the state of all filters of the cascade.
+// Filters which do not implement Stateful have an empty state.
+func (c *Cascade) State() State { return stateAll(c.filters) }
+
+// SetState restores the state of all filters of the cascade.
+// Filters which do not implement Stateful are left untouched.
+func (c *Cascade) SetState(s State) error { return setStateAll(c.filters, s) }
+
+// Reset clears the state of all bands of the bank. Bands which
+// do not implement Stateful are left untouched.
+func (b *Bank) Reset() { resetAll(b.filters) }
+
+// State returns a snapshot of the state of all bands of the bank.
+// Bands which do not implement Stateful have an empty state.
+func (b *Bank) State() State { return stateAll(b.filters) }
+
+// SetState restores the state of all bands of the bank.
+// Bands which do not implement Stateful are left untouched.
+func (b *Bank) SetState(s State) error { return setStateAll(b.filters, s) }
+
+func resetAll(filters []RecursiveFilter) {
+	for _, f := range filters {
+		if sf, ok := f.(Stateful); ok {
+			sf.Reset()
+		}
+	}
+}
+
+func stateAll(filters []RecursiveFilter) State {
+	s := State{Filters: make([]State, len(filters))}
+	for i, f := range filters {
+		if sf, ok := f.(Stateful); ok {
+			s.Filters[i] = sf.State()
+		}
+	}
+	return s
+}
+
+func setStateAll(filters []RecursiveFilter, s State) error {
+	if len(s.Filters) != len(filters) {
+		return ErrStateLength
+	}
+	for i, f := range filters {
+		if sf, ok := f.(Stateful); ok {
+			if err := sf.SetState(s.Filters[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
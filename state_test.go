@@ -0,0 +1,98 @@
+package biquad
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestState(t *testing.T) {
+	const (
+		fs = 1e3
+		N  = 200
+	)
+	x := make([]float64, N)
+	for i := range x {
+		x[i] = math.Sin(2*math.Pi*37*float64(i)/fs) + 0.3*math.Cos(2*math.Pi*210*float64(i)/fs)
+	}
+	type statefulFilter interface {
+		RecursiveFilter
+		Stateful
+	}
+	newFilters := func() []statefulFilter {
+		lp, _ := NewLowPass(fs, 50, 1)
+		sos, _ := NewButterworthBPN(fs, 50, 200, 3)
+		hp, _ := NewHighPass(fs, 20, 1)
+		sos2, _ := NewButterworthLPN(fs, 100, 4)
+		notch, _ := NewNotch(fs, 100, 1)
+		sos3, _ := NewButterworthHPN(fs, 150, 2)
+		return []statefulFilter{lp, sos, NewCascade(hp, sos2), NewBank(notch, sos3)}
+	}
+	ref, resumed := newFilters(), newFilters()
+	for i, f := range ref {
+		// Uninterrupted processing.
+		want := make([]float64, N)
+		for n := range x {
+			f.DiscreteProcess(x[n])
+			want[n] = f.YNext()
+		}
+		// Checkpoint halfway through, restore into a fresh filter and resume.
+		g := newFilters()[i]
+		for n := 0; n < N/2; n++ {
+			g.DiscreteProcess(x[n])
+		}
+		b, err := json.Marshal(g.State())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var s State
+		if err := json.Unmarshal(b, &s); err != nil {
+			t.Fatal(err)
+		}
+		r := resumed[i]
+		if err := r.SetState(s); err != nil {
+			t.Fatal(err)
+		}
+		for n := N / 2; n < N; n++ {
+			r.DiscreteProcess(x[n])
+			if got := r.YNext(); math.Abs(got-want[n]) > 1e-12 {
+				t.Fatalf("filter %d: expected %g after restore, got %g at sample %d", i, want[n], got, n)
+			}
+		}
+		// Reset filter matches a newly created filter.
+		r.Reset()
+		fresh := newFilters()[i]
+		for n := range x {
+			r.DiscreteProcess(x[n])
+			fresh.DiscreteProcess(x[n])
+			if got, want := r.YNext(), fresh.YNext(); got != want {
+				t.Fatalf("filter %d: expected %g after reset, got %g at sample %d", i, want, got, n)
+			}
+		}
+	}
+	// Impulse response is independent of prior state.
+	lp, _ := NewLowPass(fs, 50, 1)
+	want := ImpulseResponse(lp, fs, 20)
+	for n := range x {
+		lp.DiscreteProcess(x[n])
+	}
+	got := ImpulseResponse(lp, fs, 20)
+	for i := 0; i < 20; i++ {
+		_, g := got.XY(i)
+		_, w := want.XY(i)
+		if g != w {
+			t.Fatalf("expected impulse response %g, got %g at sample %d", w, g, i)
+		}
+	}
+
+	filters := newFilters()
+	if err := filters[0].SetState(filters[1].State()); err != ErrStateLength {
+		t.Errorf("expected ErrStateLength, got %v", err)
+	}
+	if err := filters[1].SetState(filters[0].State()); err != ErrStateLength {
+		t.Errorf("expected ErrStateLength, got %v", err)
+	}
+	if err := filters[2].SetState(State{}); err != ErrStateLength {
+		t.Errorf("expected ErrStateLength, got %v", err)
+	}
+}
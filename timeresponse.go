@@ -0,0 +1,154 @@
+package biquad
+
+import "math"
+
+// ImpulseResponse returns the first n samples of the response of the filter to
+// a unit impulse, sampled at Fs. Filters implementing Stateful are reset first,
+// other filters are expected to be at rest (zero state). The filter state is modified.
+// A negative n is treated as zero and yields an empty signal.
+func ImpulseResponse(f RecursiveFilter, Fs float64, n int) Signal {
+	if sf, ok := f.(Stateful); ok {
+		sf.Reset()
+	}
+	if n < 0 {
+		n = 0
+	}
+	y := make([]float64, n)
+	for i := range y {
+		x := 0.
+		if i == 0 {
+			x = 1
+		}
+		f.DiscreteProcess(x)
+		y[i] = f.YNext()
+	}
+	return MakeSignal(Fs, y)
+}
+
+// StepResponse returns the first n samples of the response of the filter to
+// a unit step, sampled at Fs. Filters implementing Stateful are reset first,
+// other filters are expected to be at rest (zero state). The filter state is modified.
+// A negative n is treated as zero and yields an empty signal.
+func StepResponse(f RecursiveFilter, Fs float64, n int) Signal {
+	if sf, ok := f.(Stateful); ok {
+		sf.Reset()
+	}
+	if n < 0 {
+		n = 0
+	}
+	y := make([]float64, n)
+	for i := range y {
+		f.DiscreteProcess(1)
+		y[i] = f.YNext()
+	}
+	return MakeSignal(Fs, y)
+}
+
+// StepInfo contains time domain characteristics of the step response of a filter.
+// Times are in seconds and measured from the first sample of the step. Rise and
+// settling times are interpolated between samples. Characteristics not reached within
+// the calculated samples are NaN.
+type StepInfo struct {
+	// Response is the step response.
+	Response Signal
+	// DCGain is the steady state value of the step response.
+	DCGain float64
+	// RiseTime is the time it takes the response to rise from 10% to 90% of DCGain.
+	RiseTime float64
+	// Overshoot is the percentage the peak response exceeds DCGain.
+	Overshoot float64
+	// Peak is the peak value of the response and PeakTime the time at which it occurs.
+	Peak, PeakTime float64
+	// SettlingTime is the time after which the response remains within
+	// the tolerance band around DCGain.
+	SettlingTime float64
+}
+
+// NewStepInfo calculates the step response of a filter over n samples sampled
+// at Fs and its characteristics. tol is the settling tolerance relative to the DC gain,
+// i.e. 0.02 for a 2% settling time. If the filter implements Responder
+// the DC gain is H(z=1), otherwise it is the last sample of the step response.
+// The filter is reset as done by StepResponse and its state is modified.
+func NewStepInfo(f RecursiveFilter, Fs float64, n int, tol float64) (StepInfo, error) {
+	switch {
+	case Fs <= 0:
+		return StepInfo{}, ErrBadFreq
+	case n < 3:
+		return StepInfo{}, ErrShortXY
+	case tol <= 0:
+		return StepInfo{}, ErrBadTolerance
+	}
+	dc := math.NaN()
+	if r, ok := f.(Responder); ok {
+		dc = real(r.Response(Fs, []float64{0})[0])
+	}
+	step := StepResponse(f, Fs, n)
+	y := make([]float64, n)
+	for i := range y {
+		_, y[i] = step.XY(i)
+	}
+	if math.IsNaN(dc) || math.IsInf(dc, 0) {
+		dc = y[n-1]
+	}
+	var ymax float64
+	for i := range y {
+		ymax = math.Max(ymax, math.Abs(y[i]))
+	}
+	if math.Abs(dc) <= 1e-12*ymax {
+		dc = 0 // Round-off of filters with zero DC gain, such as high pass filters.
+	}
+	info := StepInfo{
+		Response:     step,
+		DCGain:       dc,
+		RiseTime:     math.NaN(),
+		Overshoot:    math.NaN(),
+		SettlingTime: math.NaN(),
+	}
+	ts := 1 / Fs
+	// Peak is the largest excursion in the direction of the DC gain.
+	sign := 1.
+	if dc < 0 {
+		sign = -1
+	}
+	ipeak := 0
+	for i := range y {
+		if sign*y[i] > sign*y[ipeak] {
+			ipeak = i
+		}
+	}
+	info.Peak, info.PeakTime = y[ipeak], float64(ipeak)*ts
+	if dc == 0 {
+		return info, nil
+	}
+	info.Overshoot = math.Max(0, 100*(y[ipeak]-dc)/dc)
+	t10, t90 := crossing(y, 0.1*dc, ts), crossing(y, 0.9*dc, ts)
+	info.RiseTime = t90 - t10
+	// Settling time: last exit from the tolerance band.
+	band := tol * math.Abs(dc)
+	if math.Abs(y[n-1]-dc) > band {
+		return info, nil
+	}
+	info.SettlingTime = 0
+	for i := n - 1; i > 0; i-- {
+		if math.Abs(y[i-1]-dc) > band {
+			// Interpolate the time the response enters the band.
+			edge := dc + math.Copysign(band, y[i-1]-dc)
+			info.SettlingTime = (float64(i-1) + (edge-y[i-1])/(y[i]-y[i-1])) * ts
+			break
+		}
+	}
+	return info, nil
+}
+
+// crossing returns the interpolated time at which y first reaches level.
+// The response starts from zero so the crossing direction is given by the sign of level.
+func crossing(y []float64, level, ts float64) float64 {
+	prev := 0.
+	for i := range y {
+		if (level > 0 && y[i] >= level) || (level < 0 && y[i] <= level) {
+			return (float64(i-1) + (level-prev)/(y[i]-prev)) * ts
+		}
+		prev = y[i]
+	}
+	return math.NaN()
+}
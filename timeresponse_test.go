@@ -0,0 +1,135 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestStepInfo(t *testing.T) {
+	const (
+		fs   = 1e3
+		f0   = 50.
+		zeta = 0.3
+		tol  = 0.02
+	)
+	// Zero order hold discretization samples the analog step response exactly.
+	w0 := 2 * math.Pi * f0
+	wd := w0 * math.Sqrt(1-zeta*zeta)
+	p := complex(-zeta*w0, wd)
+	analog := ZPK{P: []complex128{p, cmplx.Conj(p)}, K: 2 * w0 * w0} // DC gain of 2.
+	s, err := Discretize(analog, fs, ZOH)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := NewStepInfo(s, fs, 200, tol)
+	if err != nil {
+		t.Fatal(err)
+	}
+	step := func(t float64) float64 {
+		return 2 * (1 - math.Exp(-zeta*w0*t)*(math.Cos(wd*t)+zeta/math.Sqrt(1-zeta*zeta)*math.Sin(wd*t)))
+	}
+	// Analog reference characteristics on a fine time grid.
+	const dt = 1e-7
+	var t10, t90, tsettle float64
+	for tt := 0.; tt < 0.2; tt += dt {
+		y := step(tt)
+		if t10 == 0 && y >= 0.2 {
+			t10 = tt
+		}
+		if t90 == 0 && y >= 1.8 {
+			t90 = tt
+		}
+		if math.Abs(y-2) > 2*tol {
+			tsettle = tt
+		}
+	}
+	wantOvershoot := 100 * math.Exp(-zeta*math.Pi/math.Sqrt(1-zeta*zeta))
+	for _, test := range []struct {
+		name      string
+		got, want float64
+		tol       float64
+	}{
+		{"DC gain", info.DCGain, 2, 1e-12},
+		{"overshoot", info.Overshoot, wantOvershoot, 0.5},
+		{"peak time", info.PeakTime, math.Pi / wd, 1 / fs},
+		{"rise time", info.RiseTime, t90 - t10, 0.1 / fs},
+		{"settling time", info.SettlingTime, tsettle, 0.1 / fs},
+	} {
+		if math.Abs(test.got-test.want) > test.tol {
+			t.Errorf("%s: expected %g, got %g", test.name, test.want, test.got)
+		}
+	}
+	if info.Response.Len() != 200 {
+		t.Errorf("expected 200 samples of step response, got %d", info.Response.Len())
+	}
+
+	// Not settled within the calculated samples.
+	s, _ = Discretize(analog, fs, ZOH)
+	info, err = NewStepInfo(s, fs, 20, tol)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !math.IsNaN(info.SettlingTime) {
+		t.Errorf("expected NaN settling time, got %g", info.SettlingTime)
+	}
+	// High pass filters have zero DC gain.
+	hp, err := NewHighPass(fs, f0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err = NewStepInfo(hp, fs, 200, tol)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(info.DCGain) > 1e-12 || !math.IsNaN(info.RiseTime) || info.PeakTime != 0 {
+		t.Errorf("expected zero DC gain, undefined rise time and peak at first sample, got %+v", info)
+	}
+	if _, err := NewStepInfo(hp, fs, 200, 0); err != ErrBadTolerance {
+		t.Errorf("expected ErrBadTolerance, got %v", err)
+	}
+	// The DC gain of filters only implementing Responder is taken from their response
+	// even if the step response has not settled yet.
+	slow, _ := NewLowPass(fs, 1, 1)
+	info, err = NewStepInfo(responderOnly{slow}, fs, 10, tol)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(info.DCGain-1) > 1e-9 {
+		t.Errorf("expected unity DC gain from Responder, got %g", info.DCGain)
+	}
+}
+
+func TestImpulseResponse(t *testing.T) {
+	const fs = 1e3
+	lp, err := NewButterworthLPN(fs, 100, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := ImpulseResponse(lp, fs, 500)
+	// The sum of the impulse response is the DC gain.
+	var sum float64
+	for i := 0; i < h.Len(); i++ {
+		ti, y := h.XY(i)
+		if math.Abs(ti-float64(i)/fs) > 1e-12 {
+			t.Fatalf("expected sample %d at %gs, got %gs", i, float64(i)/fs, ti)
+		}
+		sum += y
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("expected impulse response to sum to DC gain of 1, got %g", sum)
+	}
+	b, _ := NewBiquad(0.5, 0.25, 0, 1, 0, 0)
+	h = ImpulseResponse(b, fs, 3)
+	for i, want := range []float64{0.5, 0.25, 0} {
+		if _, got := h.XY(i); got != want {
+			t.Errorf("FIR biquad sample %d: expected %g, got %g", i, want, got)
+		}
+	}
+	if got := ImpulseResponse(b, fs, -1).Len(); got != 0 {
+		t.Errorf("expected empty impulse response for negative n, got %d samples", got)
+	}
+	if got := StepResponse(b, fs, -1).Len(); got != 0 {
+		t.Errorf("expected empty step response for negative n, got %d samples", got)
+	}
+}
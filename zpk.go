@@ -0,0 +1,410 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+// ZPK is a transfer function in zero-pole-gain form. In the analog domain
+//  H(s) = K * PROD(s - Z_i) / PROD(s - P_i)
+// and in the digital domain
+//  H(z) = K * PROD(z - Z_i) / PROD(z - P_i)
+// Complex zeros and poles must come in conjugate pairs so the
+// transfer function has real coefficients. A digital ZPK with fewer
+// zeros than poles has the missing zeros at infinity, which act as delays.
+// Analog low pass prototypes such as ButterworthPrototype may be transformed with
+// LPToLP, LPToHP, LPToBP and LPToBS and then discretized with Bilinear or Discretize.
+type ZPK struct {
+	Z, P []complex128
+	K    float64
+}
+
+// Biquad converts a digital ZPK with up to two poles and two zeros into a biquad filter.
+func (h ZPK) Biquad() (*Biquad, error) {
+	switch {
+	case len(h.P) > 2 || len(h.Z) > 2:
+		return nil, ErrBiquadOrder
+	case len(h.Z) > len(h.P):
+		return nil, ErrImproperZPK
+	case !hasConjugates(h.Z) || !hasConjugates(h.P):
+		return nil, ErrNotConjugate
+	}
+	return &Biquad{blt: h.sos().sections[0]}, nil
+}
+
+// SOS converts a digital ZPK into a cascade of second order sections.
+// Each complex pole pair is matched with the zeros nearest to it, starting
+// with the poles nearest to the unit circle. The resulting sections are ordered so that poles
+// nearest to the unit circle are last, which reduces the risk of overflow and
+// the effect of round-off noise. The gain is applied to the first section.
+func (h ZPK) SOS() (*SOS, error) {
+	if err := h.checkRealProper(); err != nil {
+		return nil, err
+	}
+	return h.sos(), nil
+}
+
+// checkRealProper checks h is a proper transfer function with real coefficients,
+// which is with no more zeros than poles and complex roots in conjugate pairs.
+func (h ZPK) checkRealProper() error {
+	switch {
+	case len(h.Z) > len(h.P):
+		return ErrImproperZPK
+	case !hasConjugates(h.Z) || !hasConjugates(h.P):
+		return ErrNotConjugate
+	}
+	return nil
+}
+
+// sos converts a digital ZPK into a cascade of second order sections.
+// It expects a proper transfer function with conjugate roots (see SOS).
+func (h ZPK) sos() *SOS {
+	pc, pr := conjugatePairs(h.P)
+	zc, zr := conjugatePairs(h.Z)
+	if len(pr)%2 == 1 {
+		// Pole and zero at the origin cancel out and yield
+		// an even amount of real poles to pair up.
+		pr = append(pr, 0)
+		zr = append(zr, 0)
+	}
+	var sections []blt
+	for len(pc) > 0 || len(pr) > 0 {
+		// Pick the pole nearest to the unit circle.
+		var p1, p2 complex128
+		ic, ir := nearestUnitCircle(pc), nearestUnitCircle(pr)
+		if ir < 0 || (ic >= 0 && unitDist(pc[ic]) <= unitDist(pr[ir])) {
+			p1 = pc[ic]
+			p2 = cmplx.Conj(p1)
+			pc = removeAt(pc, ic)
+		} else {
+			p1 = pr[ir]
+			pr = removeAt(pr, ir)
+			inear := nearest(pr, p1)
+			p2 = pr[inear]
+			pr = removeAt(pr, inear)
+		}
+		// Match up to two zeros nearest to the pole.
+		var zeros []complex128
+		izc, izr := nearest(zc, p1), nearest(zr, p1)
+		switch {
+		case izc >= 0 && (izr < 0 || cmplx.Abs(zc[izc]-p1) <= cmplx.Abs(zr[izr]-p1)):
+			zeros = append(zeros, zc[izc], cmplx.Conj(zc[izc]))
+			zc = removeAt(zc, izc)
+		case izr >= 0:
+			zeros = append(zeros, zr[izr])
+			zr = removeAt(zr, izr)
+			if i := nearest(zr, p2); i >= 0 {
+				zeros = append(zeros, zr[i])
+				zr = removeAt(zr, i)
+			}
+		}
+		sections = append(sections, sectionFromRoots(zeros, p1, p2))
+	}
+	// Sections were built starting with poles nearest to the unit circle.
+	for i, j := 0, len(sections)-1; i < j; i, j = i+1, j-1 {
+		sections[i], sections[j] = sections[j], sections[i]
+	}
+	if len(sections) == 0 {
+		// Pure gain.
+		sections = append(sections, newBLT(1, 0, 0, 1, 0, 0))
+	}
+	sections[0].b0d *= h.K
+	sections[0].b1d *= h.K
+	sections[0].b2d *= h.K
+	return &SOS{sections: sections}
+}
+
+// sectionFromRoots creates a second order section with unity gain from
+// up to two zeros and two poles (conjugate or real):
+//  H(z) = PROD(z - z_i) / ((z - p1)*(z - p2))
+// which is multiplied by z^{-2} to obtain the section in terms of z^{-1}.
+// Missing zeros appear as delays.
+func sectionFromRoots(zeros []complex128, p1, p2 complex128) blt {
+	b := [3]float64{0, 0, 1} // Both zeros at infinity.
+	switch len(zeros) {
+	case 1:
+		b = [3]float64{0, 1, -real(zeros[0])}
+	case 2:
+		b = [3]float64{1, -real(zeros[0] + zeros[1]), real(zeros[0] * zeros[1])}
+	}
+	a1, a2 := -real(p1+p2), real(p1*p2)
+	return newBLT(1, a1, a2, b[0], b[1], b[2])
+}
+
+// ZPK returns the digital zero-pole-gain representation of the biquad.
+// Matching zeros and poles at the origin (first order sections) are discarded.
+func (b *blt) ZPK() ZPK {
+	bc := [3]float64{b.b0d, b.b1d, b.b2d}
+	ac := [3]float64{1, b.a1d, b.a2d}
+	// Common factors of z^{-1} at the end of the numerator and denominator cancel out.
+	for bc[2] == 0 && ac[2] == 0 && (bc[1] != 0 || ac[1] != 0) {
+		bc = [3]float64{0, bc[0], bc[1]}
+		ac = [3]float64{0, ac[0], ac[1]}
+	}
+	// Leading zeros of the numerator are delays (zeros at infinity).
+	k := bc[0]
+	for i := 1; k == 0 && i < 3; i++ {
+		k = bc[i]
+	}
+	return ZPK{Z: quadRoots(bc), P: quadRoots(ac), K: k}
+}
+
+// ZPK returns the digital zero-pole-gain representation of the cascade.
+// Matching zeros and poles at the origin are discarded.
+func (s *SOS) ZPK() ZPK {
+	h := ZPK{K: 1}
+	for i := range s.sections {
+		sh := s.sections[i].ZPK()
+		h.Z = append(h.Z, sh.Z...)
+		h.P = append(h.P, sh.P...)
+		h.K *= sh.K
+	}
+	return h.cancelOrigin()
+}
+
+// quadRoots returns the roots in z of c[0]*z^2 + c[1]*z + c[2]. Leading zero
+// coefficients lower the degree of the polynomial.
+func quadRoots(c [3]float64) []complex128 {
+	switch {
+	case c[0] != 0:
+		disc := cmplx.Sqrt(complex(c[1]*c[1]-4*c[0]*c[2], 0))
+		// Avoid cancellation by choosing the sign of the square root.
+		q := -(complex(c[1], 0) + disc) / 2
+		if c[1] < 0 {
+			q = -(complex(c[1], 0) - disc) / 2
+		}
+		if q == 0 {
+			return []complex128{0, 0}
+		}
+		r1 := q / complex(c[0], 0)
+		r2 := complex(c[2], 0) / q
+		if imag(r1) != 0 {
+			r2 = cmplx.Conj(r1)
+		}
+		return []complex128{r1, r2}
+	case c[1] != 0:
+		return []complex128{complex(-c[2]/c[1], 0)}
+	}
+	return nil
+}
+
+// LPToLP transforms a low pass prototype with unity cutoff
+// into a low pass filter with cutoff frequency wc (rad/s).
+//  s -> s/wc
+func (h ZPK) LPToLP(wc float64) ZPK {
+	z := make([]complex128, len(h.Z))
+	p := make([]complex128, len(h.P))
+	for i := range h.Z {
+		z[i] = h.Z[i] * complex(wc, 0)
+	}
+	for i := range h.P {
+		p[i] = h.P[i] * complex(wc, 0)
+	}
+	k := h.K * math.Pow(wc, float64(len(p)-len(z)))
+	return ZPK{Z: z, P: p, K: k}
+}
+
+// LPToHP transforms a low pass prototype with unity cutoff
+// into a high pass filter with cutoff frequency wc (rad/s).
+//  s -> wc/s
+// Zeros at infinity are moved to the origin.
+func (h ZPK) LPToHP(wc float64) ZPK {
+	degree := len(h.P) - len(h.Z)
+	z := make([]complex128, 0, len(h.P))
+	p := make([]complex128, len(h.P))
+	for i := range h.Z {
+		z = append(z, complex(wc, 0)/h.Z[i])
+	}
+	for i := 0; i < degree; i++ {
+		z = append(z, 0)
+	}
+	for i := range h.P {
+		p[i] = complex(wc, 0) / h.P[i]
+	}
+	k := h.K * real(prodNeg(h.Z)/prodNeg(h.P))
+	return ZPK{Z: z, P: p, K: k}
+}
+
+// LPToBP transforms a low pass prototype with unity cutoff into a band pass
+// filter with center frequency w0 (rad/s) and bandwidth bw (rad/s).
+//  s -> (s^2 + w0^2) / (s*bw)
+// Each root is split into two and zeros at infinity are moved to the origin,
+// doubling the filter order.
+func (h ZPK) LPToBP(w0, bw float64) ZPK {
+	degree := len(h.P) - len(h.Z)
+	z := make([]complex128, 0, 2*len(h.P))
+	p := make([]complex128, 0, 2*len(h.P))
+	for i := range h.Z {
+		r1, r2 := bpRoots(h.Z[i], w0, bw)
+		z = append(z, r1, r2)
+	}
+	for i := 0; i < degree; i++ {
+		z = append(z, 0)
+	}
+	for i := range h.P {
+		r1, r2 := bpRoots(h.P[i], w0, bw)
+		p = append(p, r1, r2)
+	}
+	k := h.K * math.Pow(bw, float64(degree))
+	return ZPK{Z: z, P: p, K: k}
+}
+
+// LPToBS transforms a low pass prototype with unity cutoff into a band stop
+// filter with center frequency w0 (rad/s) and bandwidth bw (rad/s).
+//  s -> (s*bw) / (s^2 + w0^2)
+// Each root is split into two and zeros at infinity are moved to +-j*w0,
+// doubling the filter order.
+func (h ZPK) LPToBS(w0, bw float64) ZPK {
+	degree := len(h.P) - len(h.Z)
+	z := make([]complex128, 0, 2*len(h.P))
+	p := make([]complex128, 0, 2*len(h.P))
+	for i := range h.Z {
+		r1, r2 := bpRoots(1/h.Z[i], w0, bw)
+		z = append(z, r1, r2)
+	}
+	for i := 0; i < degree; i++ {
+		z = append(z, complex(0, w0), complex(0, -w0))
+	}
+	for i := range h.P {
+		r1, r2 := bpRoots(1/h.P[i], w0, bw)
+		p = append(p, r1, r2)
+	}
+	k := h.K * real(prodNeg(h.Z)/prodNeg(h.P))
+	return ZPK{Z: z, P: p, K: k}
+}
+
+// bpRoots solves s^2 - r*bw*s + w0^2 = 0 for s.
+func bpRoots(r complex128, w0, bw float64) (complex128, complex128) {
+	rbw := r * complex(bw/2, 0)
+	sq := cmplx.Sqrt(rbw*rbw - complex(w0*w0, 0))
+	return rbw + sq, rbw - sq
+}
+
+// bilinear applies the bilinear transformation to an analog transfer
+// function for a sampling frequency fs
+//  s = 2*fs * (z-1)/(z+1)
+// Zeros at infinity are mapped to z = -1 (the Nyquist frequency).
+// Frequencies should be pre-warped before calling bilinear.
+func (h ZPK) bilinear(fs float64) ZPK {
+	fs2 := complex(2*fs, 0)
+	degree := len(h.P) - len(h.Z)
+	z := make([]complex128, 0, len(h.P))
+	p := make([]complex128, len(h.P))
+	for i := range h.Z {
+		z = append(z, (fs2+h.Z[i])/(fs2-h.Z[i]))
+	}
+	for i := 0; i < degree; i++ {
+		z = append(z, -1)
+	}
+	for i := range h.P {
+		p[i] = (fs2 + h.P[i]) / (fs2 - h.P[i])
+	}
+	num, den := complex(1, 0), complex(1, 0)
+	for i := range h.Z {
+		num *= fs2 - h.Z[i]
+	}
+	for i := range h.P {
+		den *= fs2 - h.P[i]
+	}
+	return ZPK{Z: z, P: p, K: h.K * real(num/den)}
+}
+
+// prewarp returns the analog frequency in rad/s that the bilinear
+// transformation maps to the digital frequency f (in Hz).
+//  wa = 2*Fs * tan(pi*f/Fs)
+func prewarp(Fs, f float64) float64 {
+	return 2 * Fs * math.Tan(math.Pi*f/Fs)
+}
+
+// conjTol is the relative tolerance used to decide whether a root is real
+// and whether two roots are conjugates of each other.
+const conjTol = 1e-9
+
+// conjugatePairs splits roots into complex roots with positive imaginary part
+// (one per conjugate pair) and real roots. Real roots are returned sorted.
+func conjugatePairs(roots []complex128) (cplx, reals []complex128) {
+	for _, r := range roots {
+		switch {
+		case isReal(r):
+			reals = append(reals, complex(real(r), 0))
+		case imag(r) > 0:
+			cplx = append(cplx, r)
+		}
+	}
+	sort.Slice(reals, func(i, j int) bool { return real(reals[i]) < real(reals[j]) })
+	return cplx, reals
+}
+
+// hasConjugates reports whether every complex root has a matching conjugate.
+func hasConjugates(roots []complex128) bool {
+	used := make([]bool, len(roots))
+	for i, r := range roots {
+		if isReal(r) || imag(r) < 0 {
+			continue
+		}
+		found := false
+		for j, c := range roots {
+			if !used[j] && !isReal(c) && imag(c) < 0 && cmplx.Abs(c-cmplx.Conj(r)) <= conjTol*math.Max(1, cmplx.Abs(r)) {
+				used[j], found = true, true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+		used[i] = true
+	}
+	for i, r := range roots {
+		if !used[i] && !isReal(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isReal(r complex128) bool {
+	return math.Abs(imag(r)) <= conjTol*math.Max(1, cmplx.Abs(r))
+}
+
+// unitDist returns the distance from r to the unit circle.
+func unitDist(r complex128) float64 {
+	return math.Abs(1 - cmplx.Abs(r))
+}
+
+// nearestUnitCircle returns the index of the root nearest to the
+// unit circle or -1 if roots is empty.
+func nearestUnitCircle(roots []complex128) int {
+	idx := -1
+	for i := range roots {
+		if idx < 0 || unitDist(roots[i]) < unitDist(roots[idx]) {
+			idx = i
+		}
+	}
+	return idx
+}
+
+// nearest returns the index of the root nearest to r or -1 if roots is empty.
+func nearest(roots []complex128, r complex128) int {
+	idx := -1
+	for i := range roots {
+		if idx < 0 || cmplx.Abs(roots[i]-r) < cmplx.Abs(roots[idx]-r) {
+			idx = i
+		}
+	}
+	return idx
+}
+
+// removeAt removes the i-th element of s preserving order.
+func removeAt(s []complex128, i int) []complex128 {
+	return append(s[:i], s[i+1:]...)
+}
+
+// prodNeg returns PROD(-r_i).
+func prodNeg(r []complex128) complex128 {
+	prod := complex(1, 0)
+	for i := range r {
+		prod *= -r[i]
+	}
+	return prod
+}
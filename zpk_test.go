@@ -0,0 +1,182 @@
+package biquad
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestZPKBiquad(t *testing.T) {
+	const fs = 1e3
+	lp, err := NewLowPassQ(fs, 100, 0.7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notch, err := NewNotchQ(fs, 50, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	delay, err := NewBiquad(0, 0.5, 0.25, 1, -0.3, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []*blt{&lp.blt, &notch.blt, &delay.blt} {
+		h := f.ZPK()
+		bq, err := h.Biquad()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := [5]float64{bq.b0d, bq.b1d, bq.b2d, bq.a1d, bq.a2d}
+		want := [5]float64{f.b0d, f.b1d, f.b2d, f.a1d, f.a2d}
+		for i := range got {
+			if math.Abs(got[i]-want[i]) > 1e-12 {
+				t.Errorf("%v: round trip coefficients mismatch: got %v, want %v", h, got, want)
+				break
+			}
+		}
+	}
+	if _, err := NewBiquad(1, 0, 0, 0, 1, 0); err != ErrBadCoefficients {
+		t.Errorf("expected ErrBadCoefficients, got %v", err)
+	}
+}
+
+func TestZPKSOS(t *testing.T) {
+	const fs = 1e3
+	for _, design := range []func() (*SOS, error){
+		func() (*SOS, error) { return NewEllipticLP(fs, 100, 0.5, 60, 7) },
+		func() (*SOS, error) { return NewChebyshev2BS(fs, 100, 150, 40, 5) },
+		func() (*SOS, error) { return NewButterworthHPN(fs, 20, 5) },
+	} {
+		s, err := design()
+		if err != nil {
+			t.Fatal(err)
+		}
+		h := s.ZPK()
+		s2, err := h.SOS()
+		if err != nil {
+			t.Fatal(err)
+		}
+		H, H2 := s.getH(), s2.getH()
+		for _, f := range []float64{0, 10, 100, 120, 300, 499} {
+			z := cmplx.Rect(1, 2*math.Pi*f/fs)
+			if got, want := H2(z), H(z); cmplx.Abs(got-want) > 1e-9*math.Max(1, cmplx.Abs(want)) {
+				t.Errorf("round trip response at %gHz: got %v, want %v", f, got, want)
+			}
+		}
+		// Poles nearest to the unit circle are in the last section.
+		var prev float64
+		for i := range s2.sections {
+			p := s2.sections[i].ZPK().P
+			r := math.Max(cmplx.Abs(p[0]), cmplx.Abs(p[len(p)-1]))
+			if r < prev-1e-12 {
+				t.Errorf("section %d has poles farther from the unit circle than previous section", i)
+			}
+			prev = r
+		}
+	}
+	// Zeros are paired with the nearest poles.
+	h := ZPK{
+		Z: []complex128{1i, -1i, -0.9i + 0.4, 0.9i + 0.4},
+		P: []complex128{0.8i, -0.8i, 0.5, -0.5},
+		K: 1,
+	}
+	s, err := h.SOS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := s.sections[len(s.sections)-1].ZPK()
+	for _, z := range last.Z {
+		if math.Abs(cmplx.Abs(z)-1) > 1e-12 {
+			t.Errorf("expected unit circle zeros matched with poles near unit circle, got %v", last.Z)
+		}
+	}
+
+	for _, test := range []struct {
+		h   ZPK
+		err error
+	}{
+		{ZPK{Z: []complex128{1, 2}, P: []complex128{0.5}, K: 1}, ErrImproperZPK},
+		{ZPK{Z: []complex128{1i}, P: []complex128{0.5, 0.2}, K: 1}, ErrNotConjugate},
+	} {
+		if _, err := test.h.SOS(); err != test.err {
+			t.Errorf("expected %v, got %v", test.err, err)
+		}
+	}
+	if _, err := (ZPK{P: []complex128{0.1, 0.2, 0.3}}).Biquad(); err != ErrBiquadOrder {
+		t.Errorf("expected ErrBiquadOrder, got %v", err)
+	}
+	gain, err := ZPK{K: 3}.SOS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := gain.getH()(1i); got != 3 {
+		t.Errorf("expected pure gain of 3, got %v", got)
+	}
+	// Missing zeros are delays: three poles and no zeros delay the impulse by three samples.
+	delayed, err := ZPK{P: []complex128{.5, .3, .2}, K: 1}.SOS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n, want := range []float64{0, 0, 0, 1} {
+		x := 0.
+		if n == 0 {
+			x = 1
+		}
+		delayed.DiscreteProcess(x)
+		if got := delayed.YNext(); math.Abs(got-want) > 1e-15 {
+			t.Errorf("impulse response sample %d: expected %g, got %g", n, want, got)
+		}
+	}
+}
+
+func TestLPTransforms(t *testing.T) {
+	const (
+		wc = 30.
+		w0 = 100.
+		bw = 40.
+	)
+	proto, err := EllipticPrototype(3, 1, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name string
+		h    ZPK
+		s    func(s complex128) complex128 // Substitution in the prototype.
+	}{
+		{"LP", proto.LPToLP(wc), func(s complex128) complex128 { return s / wc }},
+		{"HP", proto.LPToHP(wc), func(s complex128) complex128 { return wc / s }},
+		{"BP", proto.LPToBP(w0, bw), func(s complex128) complex128 { return (s*s + w0*w0) / (s * bw) }},
+		{"BS", proto.LPToBS(w0, bw), func(s complex128) complex128 { return s * bw / (s*s + w0*w0) }},
+	} {
+		for _, w := range []float64{1, 10, 50, 99, 150, 1000} {
+			s := complex(0, w)
+			if got, want := test.h.eval(s), proto.eval(test.s(s)); cmplx.Abs(got-want) > 1e-9*math.Max(1, cmplx.Abs(want)) {
+				t.Errorf("%s at %g rad/s: expected %v, got %v", test.name, w, want, got)
+			}
+		}
+	}
+}
+
+func TestPrototypeErrors(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Butterworth order", second(ButterworthPrototype(-1)), ErrBadOrder},
+		{"Chebyshev1 order", second(Chebyshev1Prototype(0, 1)), ErrBadOrder},
+		{"Chebyshev1 ripple", second(Chebyshev1Prototype(3, 0)), ErrBadRipple},
+		{"Chebyshev2 order", second(Chebyshev2Prototype(0, 40)), ErrBadOrder},
+		{"Chebyshev2 attenuation", second(Chebyshev2Prototype(3, -1)), ErrBadRipple},
+		{"elliptic order", second(EllipticPrototype(0, 1, 40)), ErrBadOrder},
+		{"elliptic attenuation below ripple", second(EllipticPrototype(3, 3, 1)), ErrBadRipple},
+		{"Bessel order", second(BesselPrototype(-1, BesselPhase)), ErrBadOrder},
+	} {
+		if test.err != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, test.err)
+		}
+	}
+}
+
+func second(_ ZPK, err error) error { return err }